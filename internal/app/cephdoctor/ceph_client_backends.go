@@ -0,0 +1,41 @@
+package cephdoctor
+
+import (
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/appconfig"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephlocal"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephpodman"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephssh"
+)
+
+// newCephClientRouterFor builds the router over every backend. The podman client is returned as
+// well so that the caller can remove its helper containers on exit.
+func newCephClientRouterFor(
+	command *cli,
+	override domain.Backend,
+	config *appconfig.Config,
+) (*cephClientRouter, *cephpodman.CephClient) {
+	podmanClient := cephpodman.NewCephClient(cephpodman.WithSettings(podmanSettings(config)))
+
+	router := newCephClientRouter(override, map[domain.Backend]domain.CephClient{
+		domain.BackendPodman: podmanClient,
+		domain.BackendLocal:  cephlocal.NewCephClient(cephlocal.WithCommandTimeout(commandTimeout(config))),
+		domain.BackendSSH: cephssh.NewCephClient(cephssh.Config{
+			IdentityFiles:   command.SSHIdentity,
+			KnownHostsFiles: command.SSHKnownHosts,
+			UseAgent:        command.SSHAgent,
+		}, cephssh.WithCommandTimeout(commandTimeout(config))),
+	}).withConfig(config)
+
+	return router, podmanClient
+}
+
+// commandTimeout applies the config file's commandTimeout to the local and ssh backends. Zero
+// leaves the backend's built-in timeout in place.
+func commandTimeout(config *appconfig.Config) func(cluster *domain.Cluster) time.Duration {
+	return func(cluster *domain.Cluster) time.Duration {
+		return config.For(cluster.Name()).CommandTimeout
+	}
+}
@@ -0,0 +1,75 @@
+package cephdoctor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/appconfig"
+)
+
+const fallbackBackend = domain.BackendPodman
+
+// cephClientRouter sends each call to the backend chosen for the cluster.
+// A non-default override wins over the config file's per-cluster backend, then the stored
+// per-cluster setting, then the config file's default backend and finally the fallback.
+type cephClientRouter struct {
+	backends map[domain.Backend]domain.CephClient
+	override domain.Backend
+	config   *appconfig.Config
+}
+
+var _ domain.CephClient = (*cephClientRouter)(nil)
+
+func newCephClientRouter(
+	override domain.Backend,
+	backends map[domain.Backend]domain.CephClient,
+) *cephClientRouter {
+	return &cephClientRouter{
+		backends: backends,
+		override: override,
+		config:   nil,
+	}
+}
+
+// withConfig makes the router honour the backends set in the config file.
+func (r *cephClientRouter) withConfig(config *appconfig.Config) *cephClientRouter {
+	r.config = config
+
+	return r
+}
+
+func (r *cephClientRouter) Status(ctx context.Context, cluster *domain.Cluster) (*domain.CephStatus, error) {
+	client, err := r.clientFor(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Status(ctx, cluster) //nolint:wrapcheck // The router is transparent to callers.
+}
+
+func (r *cephClientRouter) clientFor(cluster *domain.Cluster) (domain.CephClient, error) {
+	backend := r.override
+	if backend == domain.BackendDefault && r.config != nil {
+		backend = domain.Backend(r.config.Clusters[cluster.Name()].Backend)
+	}
+
+	if backend == domain.BackendDefault {
+		backend = cluster.Backend()
+	}
+
+	if backend == domain.BackendDefault && r.config != nil {
+		backend = domain.Backend(r.config.Defaults.Backend)
+	}
+
+	if backend == domain.BackendDefault {
+		backend = fallbackBackend
+	}
+
+	client, ok := r.backends[backend]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUnknownBackend, backend)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,34 @@
+package cephdoctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+var errExecUnsupported = errors.New("backend cannot run arbitrary commands")
+
+var _ domain.CephExecutor = (*cephClientRouter)(nil)
+
+// Exec sends the command to the backend chosen for the cluster, like Status.
+func (r *cephClientRouter) Exec(
+	ctx context.Context,
+	cluster *domain.Cluster,
+	command domain.CephCommand,
+	stdout, stderr io.Writer,
+) (int, error) {
+	client, err := r.clientFor(cluster)
+	if err != nil {
+		return 0, err
+	}
+
+	executor, ok := client.(domain.CephExecutor)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", errExecUnsupported, cluster.Name())
+	}
+
+	return executor.Exec(ctx, cluster, command, stdout, stderr) //nolint:wrapcheck // The router is transparent to callers.
+}
@@ -0,0 +1,89 @@
+//nolint:testpackage // Command rendering is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/appconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCephClientRouter_SelectsBackend(t *testing.T) {
+	t.Parallel()
+
+	pinned, err := domain.NewCluster("pinned", "secret", []string{"10.0.0.1"}, domain.WithBackend(domain.BackendLocal))
+	require.NoError(t, err)
+
+	unpinned, err := domain.NewCluster("unpinned", "secret", []string{"10.0.0.2"})
+	require.NoError(t, err)
+
+	config := &appconfig.Config{Defaults: backendConfig("ssh"), Clusters: nil}
+	perCluster := &appconfig.Config{
+		Defaults: backendConfig("ssh"),
+		Clusters: map[string]appconfig.ClusterConfig{"pinned": backendConfig("podman")},
+	}
+
+	tests := []struct {
+		name     string
+		override domain.Backend
+		config   *appconfig.Config
+		cluster  *domain.Cluster
+		want     domain.Backend
+	}{
+		{name: "fallback", override: domain.BackendDefault, config: nil, cluster: unpinned, want: domain.BackendPodman},
+		{name: "per cluster", override: domain.BackendDefault, config: nil, cluster: pinned, want: domain.BackendLocal},
+		{name: "override wins", override: domain.BackendPodman, config: nil, cluster: pinned, want: domain.BackendPodman},
+		{name: "config default", override: domain.BackendDefault, config: config, cluster: unpinned, want: domain.BackendSSH},
+		{name: "stored beats default", override: domain.BackendDefault, config: config, cluster: pinned, want: domain.BackendLocal},
+		{name: "config per cluster", override: domain.BackendDefault, config: perCluster, cluster: pinned, want: domain.BackendPodman},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			clients := map[domain.Backend]*fakeCephClient{
+				domain.BackendPodman: newEmptyFakeCephClient(),
+				domain.BackendLocal:  newEmptyFakeCephClient(),
+				domain.BackendSSH:    newEmptyFakeCephClient(),
+			}
+			router := newCephClientRouter(test.override, map[domain.Backend]domain.CephClient{
+				domain.BackendPodman: clients[domain.BackendPodman],
+				domain.BackendLocal:  clients[domain.BackendLocal],
+				domain.BackendSSH:    clients[domain.BackendSSH],
+			}).withConfig(test.config)
+
+			_, err := router.Status(t.Context(), test.cluster)
+
+			require.NoError(t, err)
+
+			for backend, client := range clients {
+				require.Equal(t, backend == test.want, client.called, backend)
+			}
+		})
+	}
+}
+
+func TestCephClientRouter_UnknownBackend(t *testing.T) {
+	t.Parallel()
+
+	cluster, err := domain.NewCluster("pinned", "secret", []string{"10.0.0.1"}, domain.WithBackend(domain.BackendLocal))
+	require.NoError(t, err)
+
+	router := newCephClientRouter(domain.BackendDefault, map[domain.Backend]domain.CephClient{})
+
+	status, err := router.Status(t.Context(), cluster)
+
+	require.ErrorIs(t, err, domain.ErrUnknownBackend)
+	require.Nil(t, status)
+}
+
+func newEmptyFakeCephClient() *fakeCephClient {
+	return &fakeCephClient{statuses: nil, errs: nil, called: false, clusters: nil, mu: sync.Mutex{}}
+}
+
+func backendConfig(backend string) appconfig.ClusterConfig {
+	return appconfig.ClusterConfig{Image: "", CommandTimeout: 0, CleanupTimeout: 0, Backend: backend}
+}
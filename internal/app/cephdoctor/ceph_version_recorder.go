@@ -0,0 +1,39 @@
+package cephdoctor
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// cephVersionRecorder caches the release a client detected in the cluster record, so that later
+// runs can start a container image matching it. It must wrap the key resolver rather than sit
+// below it, because the cluster it stores has to keep its key reference, not the resolved key.
+type cephVersionRecorder struct {
+	next domain.CephClient
+	repo domain.ClusterRepository
+}
+
+func newCephVersionRecorder(next domain.CephClient, repo domain.ClusterRepository) *cephVersionRecorder {
+	return &cephVersionRecorder{next: next, repo: repo}
+}
+
+func (r *cephVersionRecorder) Status(ctx context.Context, cluster *domain.Cluster) (*domain.CephStatus, error) {
+	status, err := r.next.Status(ctx, cluster)
+	if err == nil && status.Version != "" && status.Version != cluster.CephVersion() {
+		r.record(ctx, cluster, status.Version)
+	}
+
+	return status, err //nolint:wrapcheck // The decorator is transparent to callers.
+}
+
+// record stores version on cluster. Only the version is written, so an edit made while the
+// status call ran is kept. Failing to cache it only costs the image match on the next run, so
+// the error is logged instead of failing the status call.
+func (r *cephVersionRecorder) record(ctx context.Context, cluster *domain.Cluster, version string) {
+	err := r.repo.RecordCephVersion(ctx, cluster.Name(), version)
+	if err != nil {
+		slog.Warn("cache ceph version", "cluster", cluster.Name(), "version", version, "error", err)
+	}
+}
@@ -0,0 +1,60 @@
+//nolint:testpackage // Command execution is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/repositorytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCephVersionRecorder_CachesDetectedVersion(t *testing.T) {
+	t.Parallel()
+
+	alpha, err := domain.NewCluster("alpha", "env:ALPHA_KEY", []string{"10.0.0.1"}, domain.WithLabels(map[string]string{
+		"env": "prod",
+	}))
+	require.NoError(t, err)
+
+	repo := repositorytest.NewMemoryRepository(alpha)
+	client := &fakeCephClient{
+		statuses: map[*domain.Cluster]*domain.CephStatus{alpha: {FSID: "1", Version: "17.2.8"}},
+		errs:     map[*domain.Cluster]error{},
+		called:   false,
+		clusters: nil,
+		mu:       sync.Mutex{},
+	}
+
+	status, err := newCephVersionRecorder(client, repo).Status(t.Context(), alpha)
+
+	require.NoError(t, err)
+	require.Equal(t, "17.2.8", status.Version)
+
+	stored := requireSingleCluster(t, repo)
+	require.Equal(t, "17.2.8", stored.CephVersion())
+	require.Equal(t, "env:ALPHA_KEY", stored.Key())
+	require.Equal(t, map[string]string{"env": "prod"}, stored.Labels())
+}
+
+func TestCephVersionRecorder_LeavesRecordWithoutNewVersion(t *testing.T) {
+	t.Parallel()
+
+	alpha, err := domain.NewCluster("alpha", "secret", []string{"10.0.0.1"}, domain.WithCephVersion("18.2.7"))
+	require.NoError(t, err)
+
+	repo := repositorytest.NewMemoryRepository(alpha)
+	client := &fakeCephClient{
+		statuses: map[*domain.Cluster]*domain.CephStatus{alpha: {FSID: "1"}},
+		errs:     map[*domain.Cluster]error{alpha: errExecFailed},
+		called:   false,
+		clusters: nil,
+		mu:       sync.Mutex{},
+	}
+
+	_, err = newCephVersionRecorder(client, repo).Status(t.Context(), alpha)
+
+	require.ErrorIs(t, err, errExecFailed)
+	require.Same(t, alpha, requireSingleCluster(t, repo))
+}
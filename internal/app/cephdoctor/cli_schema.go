@@ -1,26 +1,85 @@
 package cephdoctor
 
 type cli struct {
+	Output  outputFormat `kong:"short='o',enum='table,json,yaml,ndjson',default='table',help='Output format (table, json, yaml, ndjson).'"`
+	Backend string       `kong:"help='Backend used for every cluster (podman, local, ssh). Defaults to the per-cluster setting.'"`
+
+	Repository string `kong:"enum='file,sqlite,',default='',env='CEPHDOCTOR_REPOSITORY',help='Cluster repository (file, sqlite). Defaults to the repository config key, then file.'"`
+	Config     string `kong:"name='config',env='CEPHDOCTOR_CONFIG',help='Config file. Defaults to ceph-doctor/config.yaml in the XDG config directory.'"`
+
+	SSHIdentity   []string `kong:"name='ssh-identity',help='Private key for the ssh backend. Defaults to ~/.ssh/id_*.'"`
+	SSHKnownHosts []string `kong:"name='ssh-known-hosts',help='known_hosts file for the ssh backend. Defaults to ~/.ssh/known_hosts.'"`
+	SSHAgent      bool     `kong:"name='ssh-agent',default='true',negatable,help='Use the agent at SSH_AUTH_SOCK for the ssh backend.'"`
+
 	Cluster clusterCmd `kong:"cmd,help='Cluster operations.'"`
+	Repo    repoCmd    `kong:"cmd,help='Cluster repository maintenance.'"`
 }
 
 type clusterCmd struct {
-	Register   clusterRegisterCmd   `kong:"cmd,help='Register a cluster.'"`
-	Status     clusterStatusCmd     `kong:"cmd,help='Show status for all registered clusters.'"`
-	Unregister clusterUnregisterCmd `kong:"cmd,help='Unregister a cluster.'"`
-	List       clusterListCmd       `kong:"cmd,help='List clusters.'"`
+	Register clusterRegisterCmd `kong:"cmd,help='Register a cluster.'"`
+	Update   clusterUpdateCmd   `kong:"cmd,help='Update a registered cluster.'"`
+	Import   clusterImportCmd   `kong:"cmd,help='Import clusters from ceph.conf and keyring files or a bundle.'"`
+	Export   clusterExportCmd   `kong:"cmd,help='Export clusters to ceph.conf and keyring files or a bundle.'"`
+
+	ProvisionUser clusterProvisionUserCmd `kong:"cmd,name='provision-user',help='Print the ceph command that creates a read-only cephx user.'"`
+	Status        clusterStatusCmd        `kong:"cmd,help='Show status for all registered clusters.'"`
+	Diagnose      clusterDiagnoseCmd      `kong:"cmd,help='Diagnose registered clusters.'"`
+	Unregister    clusterUnregisterCmd    `kong:"cmd,help='Unregister a cluster.'"`
+	List          clusterListCmd          `kong:"cmd,help='List clusters.'"`
+	Exec          clusterExecCmd          `kong:"cmd,help='Run a ceph, rados, rbd or radosgw-admin command against a cluster.'"`
+	Shell         clusterShellCmd         `kong:"cmd,help='Open an interactive shell with the ceph tools in the podman container.'"`
 }
 
 type clusterRegisterCmd struct {
-	Name string `kong:"arg,help='Cluster name.'"`
-	Host string `kong:"arg,help='Host in host[:port] format.'"`
-	Key  string `kong:"arg,help='Access key.'"`
+	Name      string   `kong:"arg,help='Cluster name.'"`
+	Key       string   `kong:"arg,help='Access key, or a reference resolved on use: env:NAME, file:PATH or exec:COMMAND.'"`
+	LegacyKey string   `kong:"arg,optional,name='legacy-key',help='Deprecated NAME HOST KEY form: the second argument is then a monitor host and this is the key. Use --host.'"`
+	Hosts     []string `kong:"name='host',help='Monitor host in host[:port] format. Repeat or comma-separate for several.'"`
+	Entity    string   `kong:"name='entity',default='client.admin',help='cephx user the key belongs to, such as client.cephdoctor.'"`
+	Backend   string   `kong:"name='cluster-backend',enum='podman,local,ssh,',default='',help='Backend stored for this cluster (podman, local, ssh).'"`
+	SSH       string   `kong:"name='ssh',help='Admin node for the ssh backend in user@host[:port] format.'"`
+	Labels    []string `kong:"name='label',help='Label in key=value format, such as env=prod. Repeat or comma-separate for several.'"`
 }
 
-type clusterUnregisterCmd struct {
-	Name string `kong:"arg,help='Cluster name.'"`
+type clusterUpdateCmd struct {
+	Name         string   `kong:"arg,help='Cluster name.'"`
+	Hosts        []string `kong:"name='host',help='Replace the monitor hosts. Repeat or comma-separate for several.'"`
+	AddHosts     []string `kong:"name='add-host',help='Add a monitor host in host[:port] format.'"`
+	RemoveHosts  []string `kong:"name='remove-host',help='Remove a monitor host.'"`
+	KeyFile      string   `kong:"name='key-file',help='Read a new access key or key reference from this file, or from stdin when set to -.'"`
+	Rename       string   `kong:"name='rename',help='New cluster name.'"`
+	Entity       string   `kong:"name='entity',help='cephx user the key belongs to, such as client.cephdoctor.'"`
+	Backend      string   `kong:"name='cluster-backend',enum='podman,local,ssh,',default='',help='Backend stored for this cluster (podman, local, ssh).'"`
+	SSH          string   `kong:"name='ssh',help='Admin node for the ssh backend in user@host[:port] format.'"`
+	Labels       []string `kong:"name='label',help='Set a label in key=value format. Repeat or comma-separate for several.'"`
+	RemoveLabels []string `kong:"name='remove-label',help='Remove the label with this key.'"`
+	ResetVersion bool     `kong:"name='reset-ceph-version',help='Forget the cached ceph release so the next run detects it again.'"`
+}
+
+type clusterImportCmd struct {
+	Conf    string `kong:"name='conf',help='ceph.conf to import. The cluster is named after the file.'"`
+	Keyring string `kong:"name='keyring',help='Keyring holding the key. Defaults to <cluster>.client.admin.keyring next to --conf.'"`
+	Name    string `kong:"name='name',help='Cluster name used instead of the conf file name.'"`
+	Entity  string `kong:"name='entity',help='Keyring entry to import. Defaults to client.admin, or the only entry.'"`
+	Dir     string `kong:"name='dir',help='Import every <cluster>.conf in this directory, such as /etc/ceph.'"`
+
+	Archive        string `kong:"name='archive',help='Import every cluster in a bundle written by cluster export --archive.'"`
+	PassphraseFile string `kong:"name='passphrase-file',help='Passphrase of an encrypted bundle, read from this file or stdin when set to -.'"`
+	AllowExecKeys  bool   `kong:"name='allow-exec-keys',help='Import exec: key references from a bundle. They run their command on every use.'"`
+}
+
+type clusterExportCmd struct {
+	Name           string `kong:"arg,optional,help='Cluster name.'"`
+	All            bool   `kong:"name='all',help='Export every registered cluster.'"`
+	Dir            string `kong:"name='dir',help='Write ceph.conf and the keyring into this directory.'"`
+	Archive        string `kong:"name='archive',help='Write a tar.gz bundle to this file. Key references are kept as references.'"`
+	PassphraseFile string `kong:"name='passphrase-file',help='Encrypt bundle keys with the passphrase in this file, or stdin when set to -.'"`
 }
 
-type clusterListCmd struct{}
+type clusterProvisionUserCmd struct {
+	Entity string `kong:"name='entity',default='client.cephdoctor',help='cephx user to create.'"`
+}
 
-type clusterStatusCmd struct{}
+type clusterUnregisterCmd struct {
+	Name string `kong:"arg,help='Cluster name.'"`
+}
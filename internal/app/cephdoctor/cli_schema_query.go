@@ -0,0 +1,23 @@
+package cephdoctor
+
+import "time"
+
+type clusterListCmd struct {
+	Names    []string `kong:"arg,optional,name='name',help='Cluster names. Lists every cluster when omitted.'"`
+	Selector string   `kong:"short='l',help='Label selector, such as env=prod,dc in (seoul,busan),!canary.'"`
+}
+
+type clusterStatusCmd struct {
+	Names    []string `kong:"arg,optional,name='name',help='Cluster names. Queries every cluster when omitted.'"`
+	Selector string   `kong:"short='l',help='Label selector, such as env=prod,dc in (seoul,busan),!canary.'"`
+	Parallel int      `kong:"default='4',help='Maximum number of clusters queried concurrently.'"`
+
+	Watch    bool          `kong:"short='w',help='Poll repeatedly and highlight changes until interrupted.'"`
+	Interval time.Duration `kong:"default='10s',help='Time between polls in --watch mode.'"`
+}
+
+type clusterDiagnoseCmd struct {
+	Names    []string `kong:"arg,optional,name='name',help='Cluster names. Diagnoses every cluster when omitted.'"`
+	Selector string   `kong:"short='l',help='Label selector, such as env=prod,dc in (seoul,busan),!canary.'"`
+	Parallel int      `kong:"default='4',help='Maximum number of clusters queried concurrently.'"`
+}
@@ -0,0 +1,16 @@
+package cephdoctor
+
+type repoCmd struct {
+	Encrypt repoEncryptCmd `kong:"cmd,help='Encrypt stored cluster keys with the master key.'"`
+	Migrate repoMigrateCmd `kong:"cmd,help='Rewrite stored cluster files in the current schema version.'"`
+	Doctor  repoDoctorCmd  `kong:"cmd,help='Report, repair or quarantine broken cluster files.'"`
+}
+
+type repoEncryptCmd struct{}
+
+type repoMigrateCmd struct{}
+
+type repoDoctorCmd struct {
+	Repair     bool `kong:"help='Remove stale temporary files and move misnamed cluster files.'"`
+	Quarantine bool `kong:"help='Move corrupt cluster files out of the clusters directory.'"`
+}
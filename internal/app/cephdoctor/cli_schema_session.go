@@ -0,0 +1,11 @@
+package cephdoctor
+
+type clusterExecCmd struct {
+	Name     string   `kong:"arg,help='Cluster name.'"`
+	Command  []string `kong:"arg,help='Command to run, given after --, such as -- ceph osd tree.'"`
+	ReadOnly bool     `kong:"name='read-only',default='true',negatable,help='Only allow commands from the read-only allowlist.'"`
+}
+
+type clusterShellCmd struct {
+	Name string `kong:"arg,help='Cluster name.'"`
+}
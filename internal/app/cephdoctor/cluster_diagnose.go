@@ -0,0 +1,91 @@
+package cephdoctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/diagnosis"
+)
+
+var (
+	errClusterDiagnoseFailed = errors.New("one or more cluster diagnoses failed")
+	errCriticalFindings      = errors.New("critical findings detected")
+)
+
+func (c *clusterDiagnoseCmd) Run(
+	repo domain.ClusterRepository,
+	cephClient domain.CephClient,
+	output outputFormat,
+) error {
+	slog.Info("cluster diagnose", "names", c.Names, "selector", c.Selector)
+
+	selection, err := newClusterSelection(c.Names, c.Selector)
+	if err != nil {
+		return err
+	}
+
+	engine := diagnosis.NewEngine(diagnosis.DefaultRules()...)
+
+	return runClusterDiagnose(context.Background(), os.Stdout, repo, cephClient, engine, diagnoseOptions{
+		selection: selection,
+		parallel:  c.Parallel,
+		output:    output,
+	})
+}
+
+func (c *clusterDiagnoseCmd) Validate() error {
+	_, err := newClusterSelection(c.Names, c.Selector)
+	if err != nil {
+		return err
+	}
+
+	return validateParallel(c.Parallel)
+}
+
+// diagnoseOptions narrows and formats a diagnose run.
+type diagnoseOptions struct {
+	selection clusterSelection
+	parallel  int
+	output    outputFormat
+}
+
+func runClusterDiagnose(
+	ctx context.Context,
+	writer io.Writer,
+	repo domain.ClusterRepository,
+	cephClient domain.CephClient,
+	engine *diagnosis.Engine,
+	options diagnoseOptions,
+) error {
+	clusters, err := listSelectedClusters(ctx, repo, options.selection)
+	if err != nil {
+		return err
+	}
+
+	if len(clusters) == 0 && options.output == outputTable {
+		return writeEmptySelection(writer, options.selection)
+	}
+
+	results := collectClusterStatuses(ctx, cephClient, clusters, options.parallel)
+	diagnoses, failed, critical := diagnoseStatuses(engine, results)
+
+	err = renderDiagnoses(writer, options.output, diagnoses)
+	if err != nil {
+		return fmt.Errorf("render diagnosis: %w", err)
+	}
+
+	if failed {
+		return errClusterDiagnoseFailed
+	}
+
+	if critical {
+		return errCriticalFindings
+	}
+
+	return nil
+}
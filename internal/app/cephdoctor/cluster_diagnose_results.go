@@ -0,0 +1,31 @@
+package cephdoctor
+
+import "github.com/neatflowcv/ceph-doctor/internal/domain/diagnosis"
+
+type clusterDiagnosis struct {
+	clusterStatusView
+
+	findings []diagnosis.Finding
+}
+
+// diagnoseStatuses runs engine over every status that was collected. It also reports whether
+// any cluster could not be queried and whether any finding is critical.
+func diagnoseStatuses(engine *diagnosis.Engine, results []clusterStatusView) ([]clusterDiagnosis, bool, bool) {
+	diagnoses := make([]clusterDiagnosis, 0, len(results))
+	failed := false
+	critical := false
+
+	for _, result := range results {
+		var findings []diagnosis.Finding
+		if result.err != nil {
+			failed = true
+		} else {
+			findings = engine.Diagnose(result.status)
+			critical = critical || diagnosis.HasCritical(findings)
+		}
+
+		diagnoses = append(diagnoses, clusterDiagnosis{clusterStatusView: result, findings: findings})
+	}
+
+	return diagnoses, failed, critical
+}
@@ -0,0 +1,134 @@
+//nolint:testpackage // Command rendering is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/diagnosis"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/repositorytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunClusterDiagnose_RendersFindings(t *testing.T) {
+	t.Parallel()
+
+	alpha, err := domain.NewCluster("alpha", "secret-a", []string{"10.0.0.2"})
+	require.NoError(t, err)
+
+	zeta, err := domain.NewCluster("zeta", "secret-z", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	unavailable := new(domain.CephStatus)
+	healthy := new(domain.CephStatus)
+	healthy.MgrMap.Available = true
+
+	repo := repositorytest.NewMemoryRepository(alpha, zeta)
+	cephClient := &fakeCephClient{
+		statuses: map[*domain.Cluster]*domain.CephStatus{alpha: unavailable, zeta: healthy},
+		errs:     map[*domain.Cluster]error{},
+		called:   false,
+		clusters: nil,
+		mu:       sync.Mutex{},
+	}
+	engine := diagnosis.NewEngine(diagnosis.DefaultRules()...)
+
+	var output bytes.Buffer
+
+	err = runClusterDiagnose(t.Context(), &output, repo, cephClient, engine, diagnoseOptions{
+		selection: newTestSelection(t, nil, ""),
+		parallel:  1,
+		output:    outputTable,
+	})
+
+	require.ErrorIs(t, err, errCriticalFindings)
+	require.Equal(
+		t,
+		"=== alpha (10.0.0.2:3300) ===\n"+
+			"[CRITICAL] mgr-available: no active manager daemon\n"+
+			"  evidence: mgrmap.available=false\n"+
+			"  remediation: Start a ceph-mgr daemon; PG statistics and orchestration are unavailable without one.\n"+
+			"\n=== zeta (10.0.0.1:3300) ===\n"+
+			"[INFO] mgr-available: no standby manager daemon\n"+
+			"  evidence: mgrmap.num_standbys=0\n"+
+			"  remediation: Deploy a second ceph-mgr so a failover target exists.\n",
+		output.String(),
+	)
+}
+
+func TestRunClusterDiagnose_SelectsNamedCluster(t *testing.T) {
+	t.Parallel()
+
+	alpha, err := domain.NewCluster("alpha", "secret-a", []string{"10.0.0.2"})
+	require.NoError(t, err)
+
+	zeta, err := domain.NewCluster("zeta", "secret-z", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	repo := repositorytest.NewMemoryRepository(alpha, zeta)
+	cephClient := &fakeCephClient{
+		statuses: map[*domain.Cluster]*domain.CephStatus{},
+		errs:     map[*domain.Cluster]error{zeta: errExecFailed},
+		called:   false,
+		clusters: nil,
+		mu:       sync.Mutex{},
+	}
+
+	var output bytes.Buffer
+
+	err = runClusterDiagnose(t.Context(), &output, repo, cephClient, diagnosis.NewEngine(), diagnoseOptions{
+		selection: newTestSelection(t, []string{"zeta"}, ""),
+		parallel:  1,
+		output:    outputTable,
+	})
+
+	require.ErrorIs(t, err, errClusterDiagnoseFailed)
+	require.Equal(t, []*domain.Cluster{zeta}, cephClient.clusters)
+	require.Equal(t, "=== zeta (10.0.0.1:3300) ===\n[error] exec failed\n", output.String())
+}
+
+func TestRunClusterDiagnose_UnknownCluster(t *testing.T) {
+	t.Parallel()
+
+	repo := repositorytest.NewMemoryRepository()
+	cephClient := &fakeCephClient{statuses: nil, errs: nil, called: false, clusters: nil, mu: sync.Mutex{}}
+
+	var output bytes.Buffer
+
+	err := runClusterDiagnose(t.Context(), &output, repo, cephClient, diagnosis.NewEngine(), diagnoseOptions{
+		selection: newTestSelection(t, []string{"missing"}, ""),
+		parallel:  1,
+		output:    outputTable,
+	})
+
+	require.ErrorIs(t, err, domain.ErrClusterNotFound)
+	require.False(t, cephClient.called)
+}
+
+func TestRunClusterDiagnose_ReportsEmptySelection(t *testing.T) {
+	t.Parallel()
+
+	alpha, err := domain.NewCluster("alpha", "secret-a", []string{"10.0.0.2"})
+	require.NoError(t, err)
+
+	cephClient := &fakeCephClient{statuses: nil, errs: nil, called: false, clusters: nil, mu: sync.Mutex{}}
+
+	var unmatched, empty bytes.Buffer
+
+	unmatchedErr := runClusterDiagnose(t.Context(), &unmatched, repositorytest.NewMemoryRepository(alpha), cephClient,
+		diagnosis.NewEngine(), diagnoseOptions{
+			selection: newTestSelection(t, nil, "env=prod"),
+			parallel:  1,
+			output:    outputTable,
+		})
+	emptyErr := runClusterDiagnose(t.Context(), &empty, repositorytest.NewMemoryRepository(), cephClient,
+		diagnosis.NewEngine(), diagnoseOptions{selection: newTestSelection(t, nil, ""), parallel: 1, output: outputTable})
+
+	require.NoError(t, unmatchedErr)
+	require.NoError(t, emptyErr)
+	require.Equal(t, "No clusters match the selection.\n", unmatched.String())
+	require.Equal(t, "No clusters registered.\n", empty.String())
+	require.False(t, cephClient.called)
+}
@@ -0,0 +1,79 @@
+package cephdoctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+func (c *clusterExecCmd) Validate() error {
+	_, err := c.command()
+
+	return err
+}
+
+func (c *clusterExecCmd) Run(repo domain.ClusterRepository, executor domain.CephExecutor) error {
+	slog.Info("cluster exec", "name", c.Name, "command", c.Command, "read_only", c.ReadOnly)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return c.run(ctx, repo, executor, os.Stdout, os.Stderr)
+}
+
+// run streams the command output and turns a non-zero exit code into an ExitError,
+// so that cephdoctor exits with the same code as the command.
+func (c *clusterExecCmd) run(
+	ctx context.Context,
+	repo domain.ClusterRepository,
+	executor domain.CephExecutor,
+	stdout, stderr io.Writer,
+) error {
+	command, err := c.command()
+	if err != nil {
+		return err
+	}
+
+	clusters, err := repo.ListClusters(ctx)
+	if err != nil {
+		return fmt.Errorf("list clusters: %w", err)
+	}
+
+	selected, err := selectClusterByName(clusters, c.Name)
+	if err != nil {
+		return err
+	}
+
+	exitCode, err := executor.Exec(ctx, selected[0], command, stdout, stderr)
+	if err != nil {
+		return fmt.Errorf("exec %s: %w", command.Tool, err)
+	}
+
+	if exitCode != 0 {
+		return &ExitError{Code: exitCode}
+	}
+
+	return nil
+}
+
+func (c *clusterExecCmd) command() (domain.CephCommand, error) {
+	command, err := domain.NewCephCommand(c.Command)
+	if err != nil {
+		return domain.CephCommand{}, fmt.Errorf("parse command: %w", err)
+	}
+
+	if c.ReadOnly {
+		err = command.CheckReadOnly()
+		if err != nil {
+			return domain.CephCommand{}, fmt.Errorf("%w; pass --no-read-only to run it anyway", err)
+		}
+	}
+
+	return command, nil
+}
@@ -0,0 +1,81 @@
+//nolint:testpackage // Command execution is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/repositorytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterExecCmd_StreamsOutputAndReturnsExitCode(t *testing.T) {
+	t.Parallel()
+
+	alpha, err := domain.NewCluster("alpha", "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	executor := &fakeCephExecutor{exitCode: 2, cluster: nil, command: domain.CephCommand{Tool: "", Args: nil}}
+	command := parseCommand(t, "cluster", "exec", "alpha", "--", "ceph", "osd", "tree", "--format", "json")
+
+	var stdout, stderr bytes.Buffer
+
+	err = command.Cluster.Exec.run(t.Context(), repositorytest.NewMemoryRepository(alpha), executor, &stdout, &stderr)
+
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	require.Equal(t, 2, exitErr.Code)
+	require.Same(t, alpha, executor.cluster)
+	require.Equal(t, domain.CephCommand{Tool: "ceph", Args: []string{"osd", "tree", "--format", "json"}},
+		executor.command)
+	require.Equal(t, "ceph osd tree --format json\n", stdout.String())
+	require.Equal(t, "exit 2\n", stderr.String())
+}
+
+func TestClusterExecCmd_RejectsMutatingCommandsInReadOnlyMode(t *testing.T) {
+	t.Parallel()
+
+	err := parseCommandError(t, "cluster", "exec", "alpha", "--", "ceph", "osd", "out", "3")
+	unknownErr := parseCommandError(t, "cluster", "exec", "alpha", "--", "bash")
+	allowed := parseCommand(t, "cluster", "exec", "--no-read-only", "alpha", "--", "ceph", "osd", "out", "3")
+
+	require.ErrorIs(t, err, domain.ErrMutatingCephCommand)
+	require.ErrorIs(t, unknownErr, domain.ErrUnknownCephTool)
+	require.False(t, allowed.Cluster.Exec.ReadOnly)
+}
+
+func TestClusterExecCmd_RequiresRegisteredCluster(t *testing.T) {
+	t.Parallel()
+
+	executor := &fakeCephExecutor{exitCode: 0, cluster: nil, command: domain.CephCommand{Tool: "", Args: nil}}
+	command := parseCommand(t, "cluster", "exec", "missing", "--", "ceph", "df")
+
+	err := command.Cluster.Exec.run(t.Context(), repositorytest.NewMemoryRepository(), executor, io.Discard, io.Discard)
+
+	require.ErrorIs(t, err, domain.ErrClusterNotFound)
+	require.Nil(t, executor.cluster)
+}
+
+type fakeCephExecutor struct {
+	exitCode int
+	cluster  *domain.Cluster
+	command  domain.CephCommand
+}
+
+func (f *fakeCephExecutor) Exec(
+	_ context.Context,
+	cluster *domain.Cluster,
+	command domain.CephCommand,
+	stdout, stderr io.Writer,
+) (int, error) {
+	f.cluster, f.command = cluster, command
+
+	_, _ = fmt.Fprintln(stdout, command)
+	_, _ = fmt.Fprintf(stderr, "exit %d\n", f.exitCode)
+
+	return f.exitCode, nil
+}
@@ -0,0 +1,98 @@
+package cephdoctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephconf"
+)
+
+const exportDirPerm = 0o700
+
+var (
+	errExportTargetRequired = errors.New("exactly one of --dir or --archive is required")
+	errExportSelection      = errors.New("give either a cluster name or --all")
+	errExportDirSingle      = errors.New("--dir exports a single named cluster")
+)
+
+func (c *clusterExportCmd) Run(repo domain.ClusterRepository, resolver domain.KeyResolver) error {
+	slog.Info("cluster export", "name", c.Name, "all", c.All, "dir", c.Dir, "archive", c.Archive)
+
+	return c.run(context.Background(), os.Stdout, repo, resolver, os.Stdin)
+}
+
+func (c *clusterExportCmd) Validate() error {
+	if (c.Dir == "") == (c.Archive == "") {
+		return errExportTargetRequired
+	}
+
+	if (c.Name == "") == !c.All {
+		return errExportSelection
+	}
+
+	if c.Dir != "" && (c.All || c.PassphraseFile != "") {
+		return errExportDirSingle
+	}
+
+	return nil
+}
+
+func (c *clusterExportCmd) run(
+	ctx context.Context,
+	writer io.Writer,
+	repo domain.ClusterRepository,
+	resolver domain.KeyResolver,
+	stdin io.Reader,
+) error {
+	clusters, err := repo.ListClusters(ctx)
+	if err != nil {
+		return fmt.Errorf("list clusters: %w", err)
+	}
+
+	clusters, err = selectClusterByName(clusters, c.Name)
+	if err != nil {
+		return err
+	}
+
+	if c.Dir != "" {
+		err = exportDir(ctx, c.Dir, clusters[0], resolver)
+	} else {
+		err = c.exportArchive(clusters, stdin)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(writer, "Exported %d clusters to %s.\n", len(clusters), c.Dir+c.Archive)
+	if err != nil {
+		return fmt.Errorf("write export result: %w", err)
+	}
+
+	return nil
+}
+
+// exportDir writes a ready-to-use config, so a key reference is resolved into the keyring.
+func exportDir(ctx context.Context, dir string, cluster *domain.Cluster, resolver domain.KeyResolver) error {
+	resolved, err := resolver.ResolveKey(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("resolve cluster key: %w", err)
+	}
+
+	err = os.MkdirAll(dir, exportDirPerm)
+	if err != nil {
+		return fmt.Errorf("create export dir: %w", err)
+	}
+
+	err = cephconf.WriteDir(dir, resolved)
+	if err != nil {
+		return fmt.Errorf("write cluster config: %w", err)
+	}
+
+	return nil
+}
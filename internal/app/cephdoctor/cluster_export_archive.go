@@ -0,0 +1,43 @@
+package cephdoctor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/bundle"
+)
+
+const archivePerm = 0o600
+
+// exportArchive writes the bundle and removes the partial file when writing fails.
+func (c *clusterExportCmd) exportArchive(clusters []*domain.Cluster, stdin io.Reader) error {
+	var passphrase string
+
+	if c.PassphraseFile != "" {
+		var err error
+
+		passphrase, err = readSecret(c.PassphraseFile, stdin)
+		if err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(c.Archive, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, archivePerm)
+	if err != nil {
+		return fmt.Errorf("create archive: %w", err)
+	}
+
+	err = bundle.Write(file, clusters, []byte(passphrase))
+	err = errors.Join(err, file.Close())
+
+	if err != nil {
+		_ = os.Remove(c.Archive)
+
+		return fmt.Errorf("write archive: %w", err)
+	}
+
+	return nil
+}
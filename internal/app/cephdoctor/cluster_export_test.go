@@ -0,0 +1,127 @@
+//nolint:testpackage // Command execution is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephconf"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/secretref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterExportCmd_WritesConfigDirThatImportsBack(t *testing.T) {
+	t.Parallel()
+
+	repo := newRegisteredRepository(t)
+	dir := filepath.Join(t.TempDir(), "alpha")
+	command := parseCommand(t, "cluster", "export", "alpha", "--dir", dir)
+
+	var output bytes.Buffer
+
+	err := command.Cluster.Export.run(t.Context(), &output, repo, secretref.NewResolver(), strings.NewReader(""))
+
+	require.NoError(t, err)
+	require.Equal(t, "Exported 1 clusters to "+dir+".\n", output.String())
+
+	cluster, err := cephconf.Load(cephconf.NewSource("", "", filepath.Join(dir, cephconf.ConfigFile), ""))
+	require.NoError(t, err)
+	require.Equal(t, "ceph", cluster.Name())
+	require.Equal(t, "secret", cluster.Key())
+	require.Equal(t, []string{"10.0.0.1:3300", "10.0.0.2:3300"}, cluster.Hosts())
+}
+
+func TestClusterExportCmd_EncryptedArchiveRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	source := newRegisteredRepository(t)
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "clusters.tar.gz")
+	passphraseFile := filepath.Join(dir, "passphrase")
+	require.NoError(t, os.WriteFile(passphraseFile, []byte("hunter2\n"), 0o600))
+
+	export := parseCommand(t, "cluster", "export", "--all", "--archive", archive, "--passphrase-file", passphraseFile)
+	require.NoError(t, export.Cluster.Export.run(t.Context(), &bytes.Buffer{}, source, secretref.NewResolver(), strings.NewReader("")))
+
+	target := newEmptyRepository(t)
+	imported := parseCommand(t, "cluster", "import", "--archive", archive, "--passphrase-file", "-")
+	candidates, err := imported.Cluster.Import.candidates(strings.NewReader("hunter2\n"))
+	require.NoError(t, err)
+
+	err = runClusterImport(t.Context(), &bytes.Buffer{}, target, candidates)
+
+	require.NoError(t, err)
+	require.Equal(t, requireSingleCluster(t, source), requireSingleCluster(t, target))
+}
+
+func TestClusterExportCmd_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorIs(t, parseCommandError(t, "cluster", "export", "alpha"), errExportTargetRequired)
+	require.ErrorIs(t, parseCommandError(t, "cluster", "export", "alpha", "--all", "--archive", "a.tgz"),
+		errExportSelection)
+	require.ErrorIs(t, parseCommandError(t, "cluster", "export", "--all", "--dir", "out"), errExportDirSingle)
+	require.NoError(t, parseCommandError(t, "cluster", "export", "alpha", "--archive", "a.tgz"))
+}
+
+func TestClusterExportCmd_ResolvesKeyReferenceIntoKeyring(t *testing.T) {
+	t.Setenv("CEPHDOCTOR_TEST_EXPORT_KEY", "AQBresolved==")
+
+	repo := newEmptyRepository(t)
+	cluster, err := domain.NewCluster("alpha", "env:CEPHDOCTOR_TEST_EXPORT_KEY", []string{"10.0.0.1"})
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateCluster(t.Context(), cluster))
+
+	dir := t.TempDir()
+	command := parseCommand(t, "cluster", "export", "alpha", "--dir", dir)
+
+	err = command.Cluster.Export.run(t.Context(), &bytes.Buffer{}, repo, secretref.NewResolver(), strings.NewReader(""))
+
+	require.NoError(t, err)
+
+	keyring, err := os.ReadFile(filepath.Join(dir, cephconf.KeyringFile(domain.DefaultEntity)))
+	require.NoError(t, err)
+	require.Contains(t, string(keyring), "key = AQBresolved==")
+	require.Equal(t, "env:CEPHDOCTOR_TEST_EXPORT_KEY", requireSingleCluster(t, repo).Key())
+}
+
+func TestClusterImportCmd_RefusesArchiveExecKeysUnlessAllowed(t *testing.T) {
+	t.Parallel()
+
+	source := newEmptyRepository(t)
+	cluster, err := domain.NewCluster("alpha", "exec:pass show ceph/alpha", []string{"10.0.0.1"})
+	require.NoError(t, err)
+	require.NoError(t, source.CreateCluster(t.Context(), cluster))
+
+	archive := filepath.Join(t.TempDir(), "clusters.tar.gz")
+	export := parseCommand(t, "cluster", "export", "--all", "--archive", archive)
+	err = export.Cluster.Export.run(t.Context(), &bytes.Buffer{}, source, secretref.NewResolver(), strings.NewReader(""))
+	require.NoError(t, err)
+
+	refused := newEmptyRepository(t)
+	candidates, err := parseCommand(t, "cluster", "import", "--archive", archive).
+		Cluster.Import.candidates(strings.NewReader(""))
+	require.NoError(t, err)
+	refusedErr := runClusterImport(t.Context(), &bytes.Buffer{}, refused, candidates)
+
+	allowed := newEmptyRepository(t)
+	candidates, err = parseCommand(t, "cluster", "import", "--archive", archive, "--allow-exec-keys").
+		Cluster.Import.candidates(strings.NewReader(""))
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+
+	allowedErr := runClusterImport(t.Context(), &output, allowed, candidates)
+
+	require.ErrorIs(t, refusedErr, errArchiveExecKey)
+	clusters, err := refused.ListClusters(t.Context())
+	require.NoError(t, err)
+	require.Empty(t, clusters)
+	require.NoError(t, allowedErr)
+	require.Contains(t, output.String(), "Key is resolved on use from exec:pass show ceph/alpha.")
+	require.Equal(t, "exec:pass show ceph/alpha", requireSingleCluster(t, allowed).Key())
+}
@@ -0,0 +1,75 @@
+package cephdoctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+var (
+	errImportSourceRequired = errors.New("exactly one of --conf, --dir or --archive is required")
+	errImportDirFlags       = errors.New("--dir and --archive cannot be combined with --keyring or --name")
+)
+
+// importCandidate is one cluster to import, or the error that kept it from loading.
+type importCandidate struct {
+	origin  string
+	cluster *domain.Cluster
+	err     error
+}
+
+func (c *clusterImportCmd) Run(repo domain.ClusterRepository) error {
+	slog.Info("cluster import", "conf", c.Conf, "keyring", c.Keyring, "name", c.Name, "entity", c.Entity,
+		"dir", c.Dir, "archive", c.Archive)
+
+	candidates, err := c.candidates(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	return runClusterImport(context.Background(), os.Stdout, repo, candidates)
+}
+
+func (c *clusterImportCmd) Validate() error {
+	sources := 0
+
+	for _, value := range []string{c.Conf, c.Dir, c.Archive} {
+		if value != "" {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		return errImportSourceRequired
+	}
+
+	if c.Conf == "" && (c.Keyring != "" || c.Name != "") {
+		return errImportDirFlags
+	}
+
+	return nil
+}
+
+// runClusterImport stores every candidate it can so one broken source does not block the rest.
+func runClusterImport(
+	ctx context.Context,
+	writer io.Writer,
+	repo domain.ClusterRepository,
+	candidates []importCandidate,
+) error {
+	var errs []error
+
+	for _, candidate := range candidates {
+		err := importCluster(ctx, writer, repo, candidate)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("import %s: %w", candidate.origin, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,91 @@
+package cephdoctor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/bundle"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephconf"
+)
+
+var (
+	errNoConfFiles    = errors.New("no *.conf files found")
+	errArchiveExecKey = errors.New("archive holds an exec key reference, which runs a command on every use")
+)
+
+func (c *clusterImportCmd) candidates(stdin io.Reader) ([]importCandidate, error) {
+	if c.Archive != "" {
+		return c.archiveCandidates(stdin)
+	}
+
+	sources := []cephconf.Source{cephconf.NewSource(c.Name, c.Entity, c.Conf, c.Keyring)}
+
+	if c.Dir != "" {
+		var err error
+
+		sources, err = cephconf.FindSources(c.Dir, c.Entity)
+		if err != nil {
+			return nil, fmt.Errorf("find conf files: %w", err)
+		}
+
+		if len(sources) == 0 {
+			return nil, fmt.Errorf("%w in %s", errNoConfFiles, c.Dir)
+		}
+	}
+
+	candidates := make([]importCandidate, 0, len(sources))
+
+	for _, source := range sources {
+		cluster, err := cephconf.Load(source)
+		if err != nil {
+			err = fmt.Errorf("load cluster: %w", err)
+		}
+
+		candidates = append(candidates, importCandidate{origin: source.ConfPath, cluster: cluster, err: err})
+	}
+
+	return candidates, nil
+}
+
+func (c *clusterImportCmd) archiveCandidates(stdin io.Reader) ([]importCandidate, error) {
+	var passphrase string
+
+	if c.PassphraseFile != "" {
+		var err error
+
+		passphrase, err = readSecret(c.PassphraseFile, stdin)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := os.Open(c.Archive)
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+	defer file.Close()
+
+	clusters, err := bundle.Read(file, []byte(passphrase))
+	if err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+
+	candidates := make([]importCandidate, 0, len(clusters))
+
+	for _, cluster := range clusters {
+		candidate := importCandidate{origin: c.Archive + ":" + cluster.Name(), cluster: cluster, err: nil}
+
+		// A bundle may come from someone else, so running its commands needs explicit consent.
+		if scheme, _, _ := domain.ParseKeyReference(cluster.Key()); scheme == domain.KeySchemeExec && !c.AllowExecKeys {
+			candidate.err = fmt.Errorf("%w: %s, pass --allow-exec-keys to import it", errArchiveExecKey,
+				cluster.RedactedKey())
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
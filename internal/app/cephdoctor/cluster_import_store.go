@@ -0,0 +1,42 @@
+package cephdoctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// importCluster stores one candidate and reports it, naming the key reference it keeps so that
+// the operator sees what will be resolved on use.
+func importCluster(
+	ctx context.Context,
+	writer io.Writer,
+	repo domain.ClusterRepository,
+	candidate importCandidate,
+) error {
+	if candidate.err != nil {
+		return candidate.err
+	}
+
+	err := repo.CreateCluster(ctx, candidate.cluster)
+	if err != nil {
+		return fmt.Errorf("create cluster: %w", err)
+	}
+
+	_, err = fmt.Fprintf(writer, "Imported %s with %d monitor hosts.\n",
+		candidate.cluster.Name(), len(candidate.cluster.Hosts()))
+	if err != nil {
+		return fmt.Errorf("write import result: %w", err)
+	}
+
+	if candidate.cluster.KeyIsReference() {
+		_, err = fmt.Fprintf(writer, "  Key is resolved on use from %s.\n", candidate.cluster.RedactedKey())
+		if err != nil {
+			return fmt.Errorf("write import result: %w", err)
+		}
+	}
+
+	return nil
+}
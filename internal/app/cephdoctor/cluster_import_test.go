@@ -0,0 +1,84 @@
+//nolint:testpackage // Command execution is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterImportCmd_ImportsDirectoryAndReportsFailures(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "alpha.conf", "[global]\nmon_host = [v2:10.0.0.1:3300,v1:10.0.0.1:6789] 10.0.0.2\n")
+	writeTestFile(t, dir, "alpha.client.admin.keyring", "[client.admin]\nkey = YWxwaGE=\n")
+	writeTestFile(t, dir, "broken.conf", "[global]\nfsid = 1b2c\n")
+	writeTestFile(t, dir, "broken.client.admin.keyring", "[client.admin]\nkey = YnJva2Vu\n")
+
+	repo := newEmptyRepository(t)
+
+	command := parseCommand(t, "cluster", "import", "--dir", dir)
+	candidates, err := command.Cluster.Import.candidates(strings.NewReader(""))
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+
+	err = runClusterImport(t.Context(), &output, repo, candidates)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "broken.conf")
+	require.Equal(t, "Imported alpha with 2 monitor hosts.\n", output.String())
+
+	cluster := requireSingleCluster(t, repo)
+	require.Equal(t, "YWxwaGE=", cluster.Key())
+	require.Equal(t, []string{"10.0.0.1:3300", "10.0.0.2:3300"}, cluster.Hosts())
+}
+
+func TestClusterImportCmd_ImportsConfUnderGivenName(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "ceph.conf", "[global]\n\tmon host = 10.0.0.1:6789\n")
+	writeTestFile(t, dir, "admin.keyring", "[client.admin]\n\tkey = c2VjcmV0\n")
+
+	repo := newEmptyRepository(t)
+
+	command := parseCommand(t, "cluster", "import", "--conf", filepath.Join(dir, "ceph.conf"),
+		"--keyring", filepath.Join(dir, "admin.keyring"), "--name", "prod")
+	candidates, err := command.Cluster.Import.candidates(strings.NewReader(""))
+	require.NoError(t, err)
+
+	err = runClusterImport(t.Context(), &bytes.Buffer{}, repo, candidates)
+
+	require.NoError(t, err)
+
+	cluster := requireSingleCluster(t, repo)
+	require.Equal(t, "prod", cluster.Name())
+	require.Equal(t, []string{"10.0.0.1:6789"}, cluster.Hosts())
+
+	err = runClusterImport(t.Context(), &bytes.Buffer{}, repo, candidates)
+
+	require.ErrorIs(t, err, domain.ErrClusterAlreadyExists)
+}
+
+func TestClusterImportCmd_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorIs(t, parseCommandError(t, "cluster", "import"), errImportSourceRequired)
+	require.ErrorIs(t, parseCommandError(t, "cluster", "import", "--dir", "/etc/ceph", "--archive", "bundle.tar.gz"),
+		errImportSourceRequired)
+	require.ErrorIs(t, parseCommandError(t, "cluster", "import", "--dir", "/etc/ceph", "--name", "prod"), errImportDirFlags)
+	require.NoError(t, parseCommandError(t, "cluster", "import", "--conf", "/etc/ceph/ceph.conf"))
+}
+
+func writeTestFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
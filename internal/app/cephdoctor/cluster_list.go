@@ -2,19 +2,44 @@ package cephdoctor
 
 import (
 	"context"
-	"fmt"
+	"io"
 	"os"
 
 	"github.com/neatflowcv/ceph-doctor/internal/domain"
 )
 
-func (c *clusterListCmd) Run(repo domain.ClusterRepository) error {
-	clusters, err := repo.ListClusters(context.Background())
+func (c *clusterListCmd) Run(repo domain.ClusterRepository, output outputFormat) error {
+	selection, err := newClusterSelection(c.Names, c.Selector)
 	if err != nil {
-		return fmt.Errorf("list clusters: %w", err)
+		return err
 	}
 
-	renderClusterTable(os.Stdout, clusters)
+	return runClusterList(context.Background(), os.Stdout, repo, selection, output)
+}
+
+func (c *clusterListCmd) Validate() error {
+	_, err := newClusterSelection(c.Names, c.Selector)
+
+	return err
+}
+
+// runClusterList renders the selected clusters. An empty table is kept when nothing is
+// registered, but a selection that matches nothing is reported as such.
+func runClusterList(
+	ctx context.Context,
+	writer io.Writer,
+	repo domain.ClusterRepository,
+	selection clusterSelection,
+	output outputFormat,
+) error {
+	clusters, err := listSelectedClusters(ctx, repo, selection)
+	if err != nil {
+		return err
+	}
+
+	if len(clusters) == 0 && !selection.all() && output == outputTable {
+		return writeEmptySelection(writer, selection)
+	}
 
-	return nil
+	return renderClusters(writer, output, clusters)
 }
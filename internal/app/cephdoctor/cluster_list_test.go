@@ -0,0 +1,31 @@
+//nolint:testpackage // Command execution is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/repositorytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunClusterList_ReportsUnmatchedSelection(t *testing.T) {
+	t.Parallel()
+
+	alpha, err := domain.NewCluster("alpha", "secret-a", []string{"10.0.0.2"},
+		domain.WithLabels(map[string]string{"env": "dev"}))
+	require.NoError(t, err)
+
+	repo := repositorytest.NewMemoryRepository(alpha)
+
+	var matched, unmatched bytes.Buffer
+
+	matchedErr := runClusterList(t.Context(), &matched, repo, newTestSelection(t, nil, "env=dev"), outputTable)
+	unmatchedErr := runClusterList(t.Context(), &unmatched, repo, newTestSelection(t, nil, "env=prod"), outputTable)
+
+	require.NoError(t, matchedErr)
+	require.NoError(t, unmatchedErr)
+	require.Contains(t, matched.String(), "alpha")
+	require.Equal(t, "No clusters match the selection.\n", unmatched.String())
+}
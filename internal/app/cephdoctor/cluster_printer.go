@@ -8,15 +8,31 @@ import (
 	"github.com/neatflowcv/ceph-doctor/internal/domain"
 )
 
+func renderClusters(w io.Writer, output outputFormat, clusters []*domain.Cluster) error {
+	if output == outputTable {
+		renderClusterTable(w, clusters)
+
+		return nil
+	}
+
+	items := make([]clusterItem, 0, len(clusters))
+	for _, cluster := range clusters {
+		items = append(items, newClusterItem(cluster))
+	}
+
+	return writeDocument(w, output, clusterKind, items)
+}
+
 func renderClusterTable(w io.Writer, clusters []*domain.Cluster) {
 	tableWriter := table.NewWriter()
 	tableWriter.SetOutputMirror(w)
-	tableWriter.AppendHeader(table.Row{"Name", "Hosts"})
+	tableWriter.AppendHeader(table.Row{"Name", "Hosts", "Labels"})
 
 	for _, cluster := range clusters {
 		tableWriter.AppendRow(table.Row{
 			cluster.Name(),
 			strings.Join(cluster.Hosts(), ","),
+			domain.FormatLabels(cluster.Labels()),
 		})
 	}
 
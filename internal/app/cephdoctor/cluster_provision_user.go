@@ -0,0 +1,32 @@
+package cephdoctor
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephconf"
+)
+
+func (c *clusterProvisionUserCmd) Run() error {
+	return writeProvisionCommand(os.Stdout, c.Entity)
+}
+
+func (c *clusterProvisionUserCmd) Validate() error {
+	_, err := domain.ParseEntity(c.Entity)
+	if err != nil {
+		return fmt.Errorf("parse entity: %w", err)
+	}
+
+	return nil
+}
+
+func writeProvisionCommand(writer io.Writer, entity string) error {
+	_, err := fmt.Fprintln(writer, cephconf.ProvisionCommand(entity))
+	if err != nil {
+		return fmt.Errorf("write provision command: %w", err)
+	}
+
+	return nil
+}
@@ -2,18 +2,31 @@ package cephdoctor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/neatflowcv/ceph-doctor/internal/domain"
 )
 
+var errRegisterHostRequired = errors.New("at least one --host is required")
+
+func (c *clusterRegisterCmd) Validate() error {
+	if len(c.Hosts) == 0 && c.LegacyKey == "" {
+		return errRegisterHostRequired
+	}
+
+	return nil
+}
+
 func (c *clusterRegisterCmd) Run(repo domain.ClusterRepository) error {
-	slog.Info("cluster register", "name", c.Name, "host", c.Host)
+	slog.Info("cluster register", "name", c.Name, "hosts", c.Hosts, "entity", c.Entity,
+		"backend", c.Backend, "ssh", c.SSH, "labels", c.Labels)
 
-	cluster, err := domain.NewCluster(c.Name, c.Key, []string{c.Host})
+	cluster, err := c.cluster()
 	if err != nil {
-		return fmt.Errorf("new cluster: %w", err)
+		return err
 	}
 
 	err = repo.CreateCluster(context.Background(), cluster)
@@ -23,3 +36,47 @@ func (c *clusterRegisterCmd) Run(repo domain.ClusterRepository) error {
 
 	return nil
 }
+
+func (c *clusterRegisterCmd) cluster() (*domain.Cluster, error) {
+	labels, err := domain.ParseLabels(c.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("parse labels: %w", err)
+	}
+
+	opts := []domain.ClusterOption{
+		domain.WithBackend(domain.Backend(c.Backend)), domain.WithEntity(c.Entity), domain.WithLabels(labels),
+	}
+
+	if c.SSH != "" {
+		target, err := domain.ParseSSHTarget(c.SSH)
+		if err != nil {
+			return nil, fmt.Errorf("parse ssh target: %w", err)
+		}
+
+		opts = append(opts, domain.WithSSHTarget(target))
+	}
+
+	key, hosts := c.Key, c.Hosts
+	if c.LegacyKey != "" {
+		// The deprecated NAME HOST KEY form passes the monitor host before the key.
+		key, hosts = c.LegacyKey, append([]string{c.Key}, c.Hosts...)
+	}
+
+	cluster, err := domain.NewCluster(c.Name, key, trimHostArgs(hosts), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("new cluster: %w", err)
+	}
+
+	return cluster, nil
+}
+
+// trimHostArgs strips the whitespace left around comma-separated --host values.
+// Validation and duplicate detection are left to domain.NewHosts.
+func trimHostArgs(values []string) []string {
+	hosts := make([]string, 0, len(values))
+	for _, value := range values {
+		hosts = append(hosts, strings.TrimSpace(value))
+	}
+
+	return hosts
+}
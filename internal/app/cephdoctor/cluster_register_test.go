@@ -0,0 +1,128 @@
+//nolint:testpackage // Command parsing is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterRegisterCmd_AcceptsRepeatedAndCommaSeparatedHosts(t *testing.T) {
+	t.Parallel()
+
+	command := parseCommand(t, "cluster", "register", "alpha", "secret",
+		"--host", "10.0.0.1, 10.0.0.2:6789", "--host", "10.0.0.3")
+
+	cluster, err := command.Cluster.Register.cluster()
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.1:3300", "10.0.0.2:6789", "10.0.0.3:3300"}, cluster.Hosts())
+}
+
+func TestClusterRegisterCmd_AcceptsDeprecatedPositionalHost(t *testing.T) {
+	t.Parallel()
+
+	command := parseCommand(t, "cluster", "register", "alpha", "10.0.0.1", "secret", "--host", "10.0.0.2")
+
+	cluster, err := command.Cluster.Register.cluster()
+
+	require.NoError(t, err)
+	require.Equal(t, "secret", cluster.Key())
+	require.Equal(t, []string{"10.0.0.1:3300", "10.0.0.2:3300"}, cluster.Hosts())
+}
+
+func TestClusterRegisterCmd_RequiresHost(t *testing.T) {
+	t.Parallel()
+
+	err := parseCommandError(t, "cluster", "register", "alpha", "secret")
+
+	require.ErrorIs(t, err, errRegisterHostRequired)
+}
+
+func TestClusterRegisterCmd_RejectsDuplicateHosts(t *testing.T) {
+	t.Parallel()
+
+	command := parseCommand(t, "cluster", "register", "alpha", "secret", "--host", "10.0.0.1,10.0.0.1:3300")
+
+	cluster, err := command.Cluster.Register.cluster()
+
+	require.ErrorIs(t, err, domain.ErrDuplicateHost)
+	require.Nil(t, cluster)
+}
+
+func TestClusterRegisterCmd_RejectsEmptyHost(t *testing.T) {
+	t.Parallel()
+
+	command := parseCommand(t, "cluster", "register", "alpha", "secret", "--host", "10.0.0.1,,10.0.0.2")
+
+	cluster, err := command.Cluster.Register.cluster()
+
+	require.ErrorIs(t, err, domain.ErrEmptyHost)
+	require.Nil(t, cluster)
+}
+
+func TestClusterRegisterCmd_StoresEntity(t *testing.T) {
+	t.Parallel()
+
+	defaulted := parseCommand(t, "cluster", "register", "alpha", "secret", "--host", "10.0.0.1")
+	scoped := parseCommand(t, "cluster", "register", "alpha", "secret", "--host", "10.0.0.1",
+		"--entity", "client.cephdoctor")
+	invalid := parseCommand(t, "cluster", "register", "alpha", "secret", "--host", "10.0.0.1", "--entity", "admin")
+
+	defaultedCluster, defaultedErr := defaulted.Cluster.Register.cluster()
+	scopedCluster, scopedErr := scoped.Cluster.Register.cluster()
+	_, invalidErr := invalid.Cluster.Register.cluster()
+
+	require.NoError(t, defaultedErr)
+	require.Equal(t, domain.DefaultEntity, defaultedCluster.Entity())
+	require.NoError(t, scopedErr)
+	require.Equal(t, "client.cephdoctor", scopedCluster.Entity())
+	require.ErrorIs(t, invalidErr, domain.ErrInvalidEntity)
+}
+
+func TestClusterProvisionUserCmd(t *testing.T) {
+	t.Parallel()
+
+	command := parseCommand(t, "cluster", "provision-user")
+
+	var output bytes.Buffer
+
+	require.NoError(t, writeProvisionCommand(&output, command.Cluster.ProvisionUser.Entity))
+	require.Equal(t,
+		"ceph auth get-or-create client.cephdoctor mon 'allow r' mgr 'allow r' -o ceph.client.cephdoctor.keyring\n",
+		output.String())
+	require.ErrorIs(t, parseCommandError(t, "cluster", "provision-user", "--entity", "osd.0"), domain.ErrInvalidEntity)
+}
+
+func parseCommand(t *testing.T, args ...string) *cli {
+	t.Helper()
+
+	command, err := parseArgs(t, args...)
+	require.NoError(t, err)
+
+	return command
+}
+
+func parseCommandError(t *testing.T, args ...string) error {
+	t.Helper()
+
+	_, err := parseArgs(t, args...)
+
+	return err
+}
+
+func parseArgs(t *testing.T, args ...string) (*cli, error) {
+	t.Helper()
+
+	var command cli
+
+	parser, err := kong.New(&command, kong.Name("cephdoctor"))
+	require.NoError(t, err)
+
+	_, err = parser.Parse(args)
+
+	return &command, err //nolint:wrapcheck // Tests inspect kong errors directly.
+}
@@ -0,0 +1,80 @@
+package cephdoctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// clusterSelection narrows a command to the named clusters and those matching a label selector.
+// The zero value selects every cluster.
+type clusterSelection struct {
+	names    []string
+	selector domain.Selector
+}
+
+func newClusterSelection(names []string, expression string) (clusterSelection, error) {
+	selector, err := domain.ParseSelector(expression)
+	if err != nil {
+		return clusterSelection{names: nil, selector: selector}, fmt.Errorf("parse selector: %w", err)
+	}
+
+	return clusterSelection{names: names, selector: selector}, nil
+}
+
+// all reports whether the selection keeps every cluster.
+func (s clusterSelection) all() bool {
+	return len(s.names) == 0 && s.selector.Empty()
+}
+
+// emptyMessage explains an empty result: nothing is registered, or nothing matched.
+func (s clusterSelection) emptyMessage() string {
+	if s.all() {
+		return "No clusters registered."
+	}
+
+	return "No clusters match the selection."
+}
+
+func writeEmptySelection(writer io.Writer, selection clusterSelection) error {
+	_, err := fmt.Fprintln(writer, selection.emptyMessage())
+	if err != nil {
+		return fmt.Errorf("write empty selection: %w", err)
+	}
+
+	return nil
+}
+
+func listSelectedClusters(
+	ctx context.Context,
+	repo domain.ClusterRepository,
+	selection clusterSelection,
+) ([]*domain.Cluster, error) {
+	clusters, err := repo.ListClusters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list clusters: %w", err)
+	}
+
+	selected, err := domain.SelectClusters(clusters, selection.names, selection.selector)
+	if err != nil {
+		return nil, fmt.Errorf("select clusters: %w", err)
+	}
+
+	return selected, nil
+}
+
+func selectClusterByName(clusters []*domain.Cluster, name string) ([]*domain.Cluster, error) {
+	if name == "" {
+		return clusters, nil
+	}
+
+	for _, cluster := range clusters {
+		if cluster.Name() == name {
+			return []*domain.Cluster{cluster}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("select cluster %q: %w", name, domain.ErrClusterNotFound)
+}
@@ -0,0 +1,70 @@
+package cephdoctor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// cephShell opens an interactive session with the ceph tools set up for a cluster.
+// Only the podman backend provides one, whatever backend the cluster uses for other commands.
+type cephShell interface {
+	Shell(ctx context.Context, cluster *domain.Cluster, stdin, stdout, stderr *os.File) (int, error)
+}
+
+func (c *clusterShellCmd) Run(repo domain.ClusterRepository, resolver domain.KeyResolver, shell cephShell) error {
+	slog.Info("cluster shell", "name", c.Name)
+
+	// Ctrl-C belongs to the shell. Catching SIGINT keeps cephdoctor alive to remove the container
+	// afterwards, while podman, unlike with an ignored signal, still gets the default handler.
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+
+	defer signal.Stop(interrupts)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGHUP)
+	defer stop()
+
+	return c.run(ctx, repo, resolver, shell, os.Stdin, os.Stdout, os.Stderr)
+}
+
+// run attaches the streams to the shell and turns a non-zero exit code of its last command
+// into an ExitError.
+func (c *clusterShellCmd) run(
+	ctx context.Context,
+	repo domain.ClusterRepository,
+	resolver domain.KeyResolver,
+	shell cephShell,
+	stdin, stdout, stderr *os.File,
+) error {
+	clusters, err := repo.ListClusters(ctx)
+	if err != nil {
+		return fmt.Errorf("list clusters: %w", err)
+	}
+
+	selected, err := selectClusterByName(clusters, c.Name)
+	if err != nil {
+		return err
+	}
+
+	resolved, err := resolver.ResolveKey(ctx, selected[0])
+	if err != nil {
+		return fmt.Errorf("resolve key: %w", err)
+	}
+
+	exitCode, err := shell.Shell(ctx, resolved, stdin, stdout, stderr)
+	if err != nil {
+		return fmt.Errorf("open shell: %w", err)
+	}
+
+	if exitCode != 0 {
+		return &ExitError{Code: exitCode}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+//nolint:testpackage // Command execution is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/repositorytest"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/secretref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterShellCmd_OpensShellWithResolvedKey(t *testing.T) {
+	t.Setenv("CEPHDOCTOR_TEST_SHELL_KEY", "AQBshell==")
+
+	alpha, err := domain.NewCluster("alpha", "env:CEPHDOCTOR_TEST_SHELL_KEY", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	shell := &fakeCephShell{exitCode: 0, key: ""}
+	command := parseCommand(t, "cluster", "shell", "alpha")
+
+	err = command.Cluster.Shell.run(t.Context(), repositorytest.NewMemoryRepository(alpha), secretref.NewResolver(),
+		shell, nil, nil, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, "AQBshell==", shell.key)
+}
+
+func TestClusterShellCmd_ReturnsShellExitCode(t *testing.T) {
+	t.Parallel()
+
+	alpha, err := domain.NewCluster("alpha", "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	command := parseCommand(t, "cluster", "shell", "alpha")
+
+	err = command.Cluster.Shell.run(t.Context(), repositorytest.NewMemoryRepository(alpha), secretref.NewResolver(),
+		&fakeCephShell{exitCode: 130, key: ""}, nil, nil, nil)
+
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	require.Equal(t, 130, exitErr.Code)
+}
+
+type fakeCephShell struct {
+	exitCode int
+	key      string
+}
+
+func (f *fakeCephShell) Shell(_ context.Context, cluster *domain.Cluster, _, _, _ *os.File) (int, error) {
+	f.key = cluster.Key()
+
+	return f.exitCode, nil
+}
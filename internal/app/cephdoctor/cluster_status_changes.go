@@ -0,0 +1,60 @@
+package cephdoctor
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// changesSince lists what moved between two polls of a cluster: health transitions, new and
+// cleared checks, OSDs going up or down and PG state counts.
+func (s clusterSnapshot) changesSince(previous clusterSnapshot) []string {
+	if s.err != "" || previous.err != "" {
+		return errorChanges(previous.err, s.err)
+	}
+
+	var changes []string
+
+	if s.health != previous.health {
+		changes = append(changes, fmt.Sprintf("health: %s -> %s", previous.health, s.health))
+	}
+
+	for _, code := range slices.Sorted(maps.Keys(s.checks)) {
+		if _, ok := previous.checks[code]; !ok {
+			changes = append(changes, fmt.Sprintf("new check %s: %s", code, s.checks[code]))
+		}
+	}
+
+	for _, code := range slices.Sorted(maps.Keys(previous.checks)) {
+		if _, ok := s.checks[code]; !ok {
+			changes = append(changes, "cleared check "+code)
+		}
+	}
+
+	if s.osdsUp != previous.osdsUp || s.numOSDs != previous.numOSDs {
+		changes = append(changes, fmt.Sprintf("osd up: %d/%d -> %d/%d",
+			previous.osdsUp, previous.numOSDs, s.osdsUp, s.numOSDs))
+	}
+
+	states := maps.Clone(previous.pgs)
+	maps.Copy(states, s.pgs)
+
+	for _, state := range slices.Sorted(maps.Keys(states)) {
+		if s.pgs[state] != previous.pgs[state] {
+			changes = append(changes, fmt.Sprintf("pgs %s: %d -> %d", state, previous.pgs[state], s.pgs[state]))
+		}
+	}
+
+	return changes
+}
+
+func errorChanges(previous, current string) []string {
+	switch {
+	case previous == current:
+		return nil
+	case current == "":
+		return []string{"recovered from: " + previous}
+	default:
+		return []string{"error: " + current}
+	}
+}
@@ -0,0 +1,55 @@
+package cephdoctor
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+var errInvalidParallel = errors.New("parallel must be at least 1")
+
+// collectClusterStatuses queries clusters with at most parallel concurrent calls.
+// Results keep the order of the given clusters.
+func collectClusterStatuses(
+	ctx context.Context,
+	cephClient domain.CephClient,
+	clusters []*domain.Cluster,
+	parallel int,
+) []clusterStatusView {
+	results := make([]clusterStatusView, len(clusters))
+	jobs := make(chan int)
+
+	var waitGroup sync.WaitGroup
+
+	for range min(max(parallel, 1), len(clusters)) {
+		waitGroup.Go(func() {
+			for index := range jobs {
+				status, err := cephClient.Status(ctx, clusters[index])
+				results[index] = clusterStatusView{
+					cluster: clusters[index],
+					status:  status,
+					err:     err,
+				}
+			}
+		})
+	}
+
+	for index := range clusters {
+		jobs <- index
+	}
+
+	close(jobs)
+	waitGroup.Wait()
+
+	return results
+}
+
+func validateParallel(parallel int) error {
+	if parallel < 1 {
+		return errInvalidParallel
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+//nolint:testpackage // Command rendering is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/repositorytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectClusterStatuses_PreservesOrder(t *testing.T) {
+	t.Parallel()
+
+	clusters := newTestClusters(t, 6)
+	statuses := make(map[*domain.Cluster]*domain.CephStatus, len(clusters))
+
+	for _, cluster := range clusters {
+		status := new(domain.CephStatus)
+		status.FSID = cluster.Name()
+		statuses[cluster] = status
+	}
+
+	cephClient := &fakeCephClient{
+		statuses: statuses,
+		errs:     map[*domain.Cluster]error{clusters[3]: errExecFailed},
+		called:   false,
+		clusters: nil,
+		mu:       sync.Mutex{},
+	}
+
+	results := collectClusterStatuses(t.Context(), cephClient, clusters, 4)
+
+	require.Len(t, results, len(clusters))
+
+	for i, result := range results {
+		require.Same(t, clusters[i], result.cluster)
+		require.Equal(t, clusters[i].Name(), result.status.FSID)
+	}
+
+	require.ErrorIs(t, results[3].err, errExecFailed)
+	require.ElementsMatch(t, clusters, cephClient.clusters)
+}
+
+func TestCollectClusterStatuses_BoundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	clusters := newTestClusters(t, 8)
+	cephClient := &slowCephClient{delay: 20 * time.Millisecond, inFlight: 0, maxInFlight: 0, mu: sync.Mutex{}}
+
+	results := collectClusterStatuses(t.Context(), cephClient, clusters, 3)
+
+	require.Len(t, results, len(clusters))
+	require.Equal(t, 3, cephClient.maxInFlight)
+}
+
+func TestRunClusterStatus_ParallelKeepsSortedOutput(t *testing.T) {
+	t.Parallel()
+
+	clusters := newTestClusters(t, 5)
+	statuses := make(map[*domain.Cluster]*domain.CephStatus, len(clusters))
+
+	for _, cluster := range clusters {
+		status := new(domain.CephStatus)
+		status.Stdout = cluster.Name() + "\n"
+		statuses[cluster] = status
+	}
+
+	repo := repositorytest.NewMemoryRepository(clusters...)
+	cephClient := &fakeCephClient{statuses: statuses, errs: nil, called: false, clusters: nil, mu: sync.Mutex{}}
+
+	var first, second bytes.Buffer
+
+	all := newTestSelection(t, nil, "")
+
+	require.NoError(t, runClusterStatus(t.Context(), &first, repo, cephClient, all, 5, outputTable))
+	require.NoError(t, runClusterStatus(t.Context(), &second, repo, cephClient, all, 1, outputTable))
+	require.Equal(t, second.String(), first.String())
+}
+
+func newTestClusters(t *testing.T, count int) []*domain.Cluster {
+	t.Helper()
+
+	clusters := make([]*domain.Cluster, 0, count)
+
+	for i := range count {
+		cluster, err := domain.NewCluster(fmt.Sprintf("cluster-%02d", i), "secret", []string{fmt.Sprintf("10.0.0.%d", i+1)})
+		require.NoError(t, err)
+
+		clusters = append(clusters, cluster)
+	}
+
+	return clusters
+}
+
+type slowCephClient struct {
+	delay       time.Duration
+	inFlight    int
+	maxInFlight int
+	mu          sync.Mutex
+}
+
+func (s *slowCephClient) Status(context.Context, *domain.Cluster) (*domain.CephStatus, error) {
+	s.mu.Lock()
+	s.inFlight++
+	s.maxInFlight = max(s.maxInFlight, s.inFlight)
+	s.mu.Unlock()
+
+	time.Sleep(s.delay)
+
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+
+	return new(domain.CephStatus), nil
+}
@@ -0,0 +1,75 @@
+package cephdoctor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+func renderClusterStatusResults(writer io.Writer, output outputFormat, results []clusterStatusView) error {
+	if output != outputTable {
+		items := make([]clusterStatusItem, 0, len(results))
+		for _, result := range results {
+			items = append(items, newClusterStatusItem(result))
+		}
+
+		return writeDocument(writer, output, clusterStatusKind, items)
+	}
+
+	for i, result := range results {
+		err := renderClusterStatusResult(writer, i, result)
+		if err != nil {
+			return fmt.Errorf("render cluster status result: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func renderClusterStatusResult(
+	writer io.Writer,
+	index int,
+	result clusterStatusView,
+) error {
+	err := writeStatusHeader(writer, index, result.cluster)
+	if err != nil {
+		return err
+	}
+
+	err = writeCephStatusStreams(writer, result.status)
+	if err != nil {
+		return err
+	}
+
+	if result.err != nil {
+		_, err = fmt.Fprintf(writer, "[error] %v\n", result.err)
+		if err != nil {
+			return fmt.Errorf("write status error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func writeStatusHeader(writer io.Writer, index int, cluster *domain.Cluster) error {
+	if index > 0 {
+		_, err := fmt.Fprintln(writer)
+		if err != nil {
+			return fmt.Errorf("write status separator: %w", err)
+		}
+	}
+
+	_, err := fmt.Fprintf(
+		writer,
+		"=== %s (%s) ===\n",
+		cluster.Name(),
+		strings.Join(cluster.Hosts(), ","),
+	)
+	if err != nil {
+		return fmt.Errorf("write status header: %w", err)
+	}
+
+	return nil
+}
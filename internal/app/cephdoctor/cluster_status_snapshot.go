@@ -0,0 +1,73 @@
+package cephdoctor
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// clusterSnapshot is the part of a cluster status that watch mode shows and compares between polls.
+type clusterSnapshot struct {
+	health  domain.HealthStatus
+	checks  map[string]string
+	pgs     map[string]int
+	numPGs  int
+	osdsUp  int
+	numOSDs int
+	err     string
+}
+
+func newClusterSnapshot(view clusterStatusView) clusterSnapshot {
+	snapshot := clusterSnapshot{
+		health: "", checks: map[string]string{}, pgs: map[string]int{}, numPGs: 0, osdsUp: 0, numOSDs: 0, err: "",
+	}
+
+	if view.err != nil || view.status == nil || view.status.FSID == "" {
+		snapshot.err = "no status"
+		if view.err != nil {
+			snapshot.err = view.err.Error()
+		}
+
+		return snapshot
+	}
+
+	status := view.status
+	snapshot.health = status.Health.Status
+	snapshot.numPGs = status.PGMap.NumPGs
+	snapshot.osdsUp = status.OSDMap.NumUpOSDs
+	snapshot.numOSDs = status.OSDMap.NumOSDs
+
+	for _, check := range status.Health.Checks {
+		snapshot.checks[check.Code] = check.Message
+	}
+
+	for _, state := range status.PGMap.States {
+		snapshot.pgs[state.State] = state.Count
+	}
+
+	return snapshot
+}
+
+// summary renders the snapshot on one line, such as
+// "HEALTH_WARN  osd 5/6 up  pgs 97: 93 active+clean, 4 active+undersized+degraded  OSD_DOWN".
+func (s clusterSnapshot) summary() string {
+	if s.err != "" {
+		return "ERROR  " + s.err
+	}
+
+	states := make([]string, 0, len(s.pgs))
+	for _, state := range slices.Sorted(maps.Keys(s.pgs)) {
+		states = append(states, fmt.Sprintf("%d %s", s.pgs[state], state))
+	}
+
+	line := fmt.Sprintf("%s  osd %d/%d up  pgs %d: %s", s.health, s.osdsUp, s.numOSDs, s.numPGs,
+		strings.Join(states, ", "))
+	if len(s.checks) > 0 {
+		line += "  " + strings.Join(slices.Sorted(maps.Keys(s.checks)), ",")
+	}
+
+	return line
+}
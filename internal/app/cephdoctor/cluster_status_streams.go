@@ -0,0 +1,62 @@
+package cephdoctor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+func writeCephStatusStreams(writer io.Writer, status *domain.CephStatus) error {
+	if status == nil {
+		return nil
+	}
+
+	var err error
+	if status.FSID != "" {
+		err = writeStatusSummary(writer, status)
+	} else {
+		err = writeStatusStream(writer, status.Stdout)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if status.Stderr == "" {
+		return nil
+	}
+
+	_, err = fmt.Fprintln(writer, "[stderr]")
+	if err != nil {
+		return fmt.Errorf("write stderr label: %w", err)
+	}
+
+	err = writeStatusStream(writer, status.Stderr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func writeStatusStream(writer io.Writer, content string) error {
+	if content == "" {
+		return nil
+	}
+
+	_, err := io.WriteString(writer, content)
+	if err != nil {
+		return fmt.Errorf("write status stream: %w", err)
+	}
+
+	if !strings.HasSuffix(content, "\n") {
+		_, err = fmt.Fprintln(writer)
+		if err != nil {
+			return fmt.Errorf("terminate status stream: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package cephdoctor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+const byteUnit = 1024
+
+func writeStatusSummary(writer io.Writer, status *domain.CephStatus) error {
+	lines := []string{
+		"  id:     " + status.FSID,
+		"  health: " + string(status.Health.Status),
+	}
+
+	for _, check := range status.Health.Checks {
+		lines = append(lines, fmt.Sprintf("          %s: %s", check.Code, check.Message))
+	}
+
+	mgrState := "unavailable"
+	if status.MgrMap.Available {
+		mgrState = "available"
+	}
+
+	lines = append(lines,
+		fmt.Sprintf("  mon:    %d daemons, quorum %s",
+			status.MonMap.NumMons, strings.Join(status.MonMap.QuorumNames, ",")),
+		fmt.Sprintf("  mgr:    %s, %d standbys", mgrState, status.MgrMap.NumStandbys),
+		fmt.Sprintf("  osd:    %d osds: %d up, %d in",
+			status.OSDMap.NumOSDs, status.OSDMap.NumUpOSDs, status.OSDMap.NumInOSDs),
+		fmt.Sprintf("  pgs:    %d pgs: %s", status.PGMap.NumPGs, formatPGStates(status.PGMap.States)),
+		fmt.Sprintf("  usage:  %s used, %s / %s avail",
+			formatBytes(status.Usage.UsedBytes),
+			formatBytes(status.Usage.AvailBytes),
+			formatBytes(status.Usage.TotalBytes)),
+		fmt.Sprintf("  io:     %s/s rd, %s/s wr, %d op/s rd, %d op/s wr",
+			formatBytes(uint64(max(status.IO.ReadBytesPerSec, 0))),
+			formatBytes(uint64(max(status.IO.WriteBytesPerSec, 0))),
+			status.IO.ReadOpsPerSec,
+			status.IO.WriteOpsPerSec),
+	)
+
+	_, err := io.WriteString(writer, strings.Join(lines, "\n")+"\n")
+	if err != nil {
+		return fmt.Errorf("write status summary: %w", err)
+	}
+
+	return nil
+}
+
+func formatPGStates(states []domain.PGStateCount) string {
+	parts := make([]string, 0, len(states))
+	for _, state := range states {
+		parts = append(parts, fmt.Sprintf("%d %s", state.Count, state.State))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func formatBytes(value uint64) string {
+	if value < byteUnit {
+		return fmt.Sprintf("%d B", value)
+	}
+
+	units := []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	scaled := float64(value) / byteUnit
+	unit := 0
+
+	for scaled >= byteUnit && unit < len(units)-1 {
+		scaled /= byteUnit
+		unit++
+	}
+
+	return fmt.Sprintf("%.1f %s", scaled, units[unit])
+}
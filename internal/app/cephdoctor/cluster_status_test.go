@@ -5,24 +5,25 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/repositorytest"
 	"github.com/stretchr/testify/require"
 )
 
 var errExecFailed = errors.New("exec failed")
-var errNotImplemented = errors.New("not implemented")
 
 func TestRunClusterStatus_EmptyRepository(t *testing.T) {
 	t.Parallel()
 
-	repo := &fakeClusterRepository{clusters: nil, err: nil}
-	cephClient := &fakeCephClient{statuses: nil, errs: nil, called: false, clusters: nil}
+	repo := repositorytest.NewMemoryRepository()
+	cephClient := &fakeCephClient{statuses: nil, errs: nil, called: false, clusters: nil, mu: sync.Mutex{}}
 
 	var output bytes.Buffer
 
-	err := runClusterStatus(t.Context(), &output, repo, cephClient)
+	err := runClusterStatus(t.Context(), &output, repo, cephClient, newTestSelection(t, nil, ""), 1, outputTable)
 
 	require.NoError(t, err)
 	require.Equal(t, "No clusters registered.\n", output.String())
@@ -38,10 +39,7 @@ func TestRunClusterStatus_RendersResults(t *testing.T) {
 	zeta, err := domain.NewCluster("zeta", "secret-z", []string{"10.0.0.1:4400"})
 	require.NoError(t, err)
 
-	repo := &fakeClusterRepository{
-		clusters: []*domain.Cluster{alpha, zeta},
-		err:      nil,
-	}
+	repo := repositorytest.NewMemoryRepository(alpha, zeta)
 	cephClient := &fakeCephClient{
 		statuses: map[*domain.Cluster]*domain.CephStatus{
 			alpha: {
@@ -56,11 +54,12 @@ func TestRunClusterStatus_RendersResults(t *testing.T) {
 		errs:     map[*domain.Cluster]error{},
 		called:   false,
 		clusters: nil,
+		mu:       sync.Mutex{},
 	}
 
 	var output bytes.Buffer
 
-	err = runClusterStatus(t.Context(), &output, repo, cephClient)
+	err = runClusterStatus(t.Context(), &output, repo, cephClient, newTestSelection(t, nil, ""), 1, outputTable)
 
 	require.NoError(t, err)
 	require.True(t, cephClient.called)
@@ -82,10 +81,7 @@ func TestRunClusterStatus_ReturnsErrorWhenAnyClusterFails(t *testing.T) {
 	zeta, err := domain.NewCluster("zeta", "secret-z", []string{"10.0.0.1"})
 	require.NoError(t, err)
 
-	repo := &fakeClusterRepository{
-		clusters: []*domain.Cluster{alpha, zeta},
-		err:      nil,
-	}
+	repo := repositorytest.NewMemoryRepository(alpha, zeta)
 	cephClient := &fakeCephClient{
 		statuses: map[*domain.Cluster]*domain.CephStatus{
 			alpha: {
@@ -102,11 +98,12 @@ func TestRunClusterStatus_ReturnsErrorWhenAnyClusterFails(t *testing.T) {
 		},
 		called:   false,
 		clusters: nil,
+		mu:       sync.Mutex{},
 	}
 
 	var output bytes.Buffer
 
-	err = runClusterStatus(t.Context(), &output, repo, cephClient)
+	err = runClusterStatus(t.Context(), &output, repo, cephClient, newTestSelection(t, nil, ""), 1, outputTable)
 
 	require.ErrorIs(t, err, errClusterStatusFailed)
 	require.Contains(t, output.String(), "=== alpha (10.0.0.2:3300) ===")
@@ -115,29 +112,120 @@ func TestRunClusterStatus_ReturnsErrorWhenAnyClusterFails(t *testing.T) {
 	require.Contains(t, output.String(), "still-ran")
 }
 
-type fakeClusterRepository struct {
-	clusters []*domain.Cluster
-	err      error
-}
+func TestRunClusterStatus_RendersParsedSummary(t *testing.T) {
+	t.Parallel()
 
-func (f *fakeClusterRepository) CreateCluster(context.Context, *domain.Cluster) error {
-	return errNotImplemented
-}
+	alpha, err := domain.NewCluster("alpha", "secret-a", []string{"10.0.0.2"})
+	require.NoError(t, err)
 
-func (f *fakeClusterRepository) UpdateCluster(context.Context, *domain.Cluster) error {
-	return errNotImplemented
+	repo := repositorytest.NewMemoryRepository(alpha)
+	cephClient := &fakeCephClient{
+		statuses: map[*domain.Cluster]*domain.CephStatus{
+			alpha: {
+				FSID: "fsid-1",
+				Health: domain.Health{
+					Status: domain.HealthWarn,
+					Checks: []domain.HealthCheck{
+						{Code: "OSD_DOWN", Severity: domain.HealthWarn, Message: "1 osds down", Count: 1, Muted: false},
+					},
+				},
+				MonMap: domain.MonMap{Epoch: 1, NumMons: 3, QuorumNames: []string{"a", "b", "c"}},
+				MgrMap: domain.MgrMap{Available: true, NumStandbys: 1, Modules: nil},
+				OSDMap: domain.OSDMap{Epoch: 1, NumOSDs: 3, NumUpOSDs: 2, NumInOSDs: 3, NumRemappedPGs: 0},
+				PGMap: domain.PGMap{
+					NumPGs:           97,
+					NumPools:         2,
+					NumObjects:       0,
+					States:           []domain.PGStateCount{{State: "active+clean", Count: 97}},
+					DegradedObjects:  0,
+					DegradedRatio:    0,
+					MisplacedObjects: 0,
+					MisplacedRatio:   0,
+				},
+				Usage:  domain.Usage{DataBytes: 0, UsedBytes: 2048, AvailBytes: 3 << 30, TotalBytes: 3 << 30},
+				IO:     domain.IORates{ReadBytesPerSec: 512, WriteBytesPerSec: 0, ReadOpsPerSec: 1, WriteOpsPerSec: 0},
+				Stdout: "{}",
+				Stderr: "",
+			},
+		},
+		errs:     map[*domain.Cluster]error{},
+		called:   false,
+		clusters: nil,
+		mu:       sync.Mutex{},
+	}
+
+	var output bytes.Buffer
+
+	err = runClusterStatus(t.Context(), &output, repo, cephClient, newTestSelection(t, nil, ""), 1, outputTable)
+
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"=== alpha (10.0.0.2:3300) ===\n"+
+			"  id:     fsid-1\n"+
+			"  health: HEALTH_WARN\n"+
+			"          OSD_DOWN: 1 osds down\n"+
+			"  mon:    3 daemons, quorum a,b,c\n"+
+			"  mgr:    available, 1 standbys\n"+
+			"  osd:    3 osds: 2 up, 3 in\n"+
+			"  pgs:    97 pgs: 97 active+clean\n"+
+			"  usage:  2.0 KiB used, 3.0 GiB / 3.0 GiB avail\n"+
+			"  io:     512 B/s rd, 0 B/s wr, 1 op/s rd, 0 op/s wr\n",
+		output.String(),
+	)
 }
 
-func (f *fakeClusterRepository) ListClusters(context.Context) ([]*domain.Cluster, error) {
-	if f.err != nil {
-		return nil, f.err
+func TestRunClusterStatus_FiltersBySelector(t *testing.T) {
+	t.Parallel()
+
+	prod, err := domain.NewCluster("alpha", "secret-a", []string{"10.0.0.2"},
+		domain.WithLabels(map[string]string{"env": "prod", "dc": "seoul"}))
+	require.NoError(t, err)
+
+	dev, err := domain.NewCluster("beta", "secret-b", []string{"10.0.0.3"},
+		domain.WithLabels(map[string]string{"env": "dev"}))
+	require.NoError(t, err)
+
+	repo := repositorytest.NewMemoryRepository(prod, dev)
+	status := &domain.CephStatus{Stdout: "ok\n", Stderr: ""}
+	cephClient := &fakeCephClient{
+		statuses: map[*domain.Cluster]*domain.CephStatus{prod: status, dev: status},
+		errs:     nil,
+		called:   false,
+		clusters: nil,
+		mu:       sync.Mutex{},
 	}
 
-	return f.clusters, nil
+	var matched, unmatched bytes.Buffer
+
+	err = runClusterStatus(t.Context(), &matched, repo, cephClient,
+		newTestSelection(t, nil, "env in (prod,staging),dc"), 1, outputTable)
+	require.NoError(t, err)
+
+	err = runClusterStatus(t.Context(), &unmatched, repo, cephClient,
+		newTestSelection(t, []string{"beta"}, "env=prod"), 1, outputTable)
+	require.NoError(t, err)
+
+	require.Equal(t, []*domain.Cluster{prod}, cephClient.clusters)
+	require.Equal(t, "=== alpha (10.0.0.2:3300) ===\nok\n", matched.String())
+	require.Equal(t, "No clusters match the selection.\n", unmatched.String())
+}
+
+func TestClusterStatusCmd_RejectsInvalidSelector(t *testing.T) {
+	t.Parallel()
+
+	err := parseCommandError(t, "cluster", "status", "-l", "dc in (seoul")
+
+	require.ErrorIs(t, err, domain.ErrInvalidSelector)
 }
 
-func (f *fakeClusterRepository) DeleteCluster(context.Context, string) error {
-	return errNotImplemented
+func newTestSelection(t *testing.T, names []string, expression string) clusterSelection {
+	t.Helper()
+
+	selection, err := newClusterSelection(names, expression)
+	require.NoError(t, err)
+
+	return selection
 }
 
 type fakeCephClient struct {
@@ -145,9 +233,13 @@ type fakeCephClient struct {
 	errs     map[*domain.Cluster]error
 	called   bool
 	clusters []*domain.Cluster
+	mu       sync.Mutex
 }
 
 func (f *fakeCephClient) Status(_ context.Context, cluster *domain.Cluster) (*domain.CephStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	f.called = true
 	f.clusters = append(f.clusters, cluster)
 
@@ -0,0 +1,90 @@
+package cephdoctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"golang.org/x/term"
+)
+
+var (
+	errInvalidInterval = errors.New("interval must be at least 1s")
+	errWatchOutput     = errors.New("--watch only supports table output")
+)
+
+// watchOptions configures watch mode. On a terminal each poll redraws the screen and changes are
+// coloured; otherwise polls are appended one after another.
+type watchOptions struct {
+	selection clusterSelection
+	parallel  int
+	interval  time.Duration
+	terminal  bool
+}
+
+// watch runs watchClusterStatus on stdout until Ctrl-C or SIGTERM.
+func (c *clusterStatusCmd) watch(
+	repo domain.ClusterRepository,
+	cephClient domain.CephClient,
+	selection clusterSelection,
+	output outputFormat,
+) error {
+	if output != outputTable {
+		return errWatchOutput
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return watchClusterStatus(ctx, os.Stdout, repo, cephClient, watchOptions{
+		selection: selection,
+		parallel:  c.Parallel,
+		interval:  c.Interval,
+		terminal:  term.IsTerminal(int(os.Stdout.Fd())), //nolint:gosec // File descriptors fit in int.
+	})
+}
+
+// watchClusterStatus polls the selected clusters every interval until ctx is cancelled, which ends
+// the watch without an error. Clusters are listed again on every poll, so registrations show up.
+func watchClusterStatus(
+	ctx context.Context,
+	writer io.Writer,
+	repo domain.ClusterRepository,
+	cephClient domain.CephClient,
+	options watchOptions,
+) error {
+	previous := map[string]clusterSnapshot{}
+
+	for {
+		clusters, err := listSelectedClusters(ctx, repo, options.selection)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		results := collectClusterStatuses(ctx, cephClient, clusters, options.parallel)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		err = writeWatchFrame(writer, options, results, previous)
+		if err != nil {
+			return fmt.Errorf("render cluster status: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(options.interval):
+		}
+	}
+}
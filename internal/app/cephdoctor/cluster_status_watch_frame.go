@@ -0,0 +1,80 @@
+package cephdoctor
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+const clearScreen = "\x1b[H\x1b[2J"
+
+// writeWatchFrame draws one poll and replaces previous with its snapshots.
+func writeWatchFrame(
+	writer io.Writer,
+	options watchOptions,
+	results []clusterStatusView,
+	previous map[string]clusterSnapshot,
+) error {
+	lines := []string{fmt.Sprintf("Every %s: cluster status  %s  (Ctrl-C to exit)", options.interval,
+		time.Now().Format(time.DateTime)), ""}
+	width := 0
+
+	for _, result := range results {
+		width = max(width, len(result.cluster.Name()))
+	}
+
+	current := make(map[string]clusterSnapshot, len(results))
+
+	for _, result := range results {
+		name := result.cluster.Name()
+		snapshot := newClusterSnapshot(result)
+		current[name] = snapshot
+
+		var changes []string
+		if last, ok := previous[name]; ok {
+			changes = snapshot.changesSince(last)
+		}
+
+		lines = append(lines, highlight(fmt.Sprintf("%-*s  %s", width, name, snapshot.summary()),
+			len(changes) > 0 && options.terminal))
+
+		for _, change := range changes {
+			lines = append(lines, highlight("  * "+change, options.terminal))
+		}
+	}
+
+	if len(results) == 0 {
+		lines = append(lines, options.selection.emptyMessage())
+	}
+
+	prefix := ""
+
+	switch {
+	case options.terminal:
+		prefix = clearScreen
+	case len(previous) > 0:
+		prefix = "\n"
+	}
+
+	clear(previous)
+	maps.Copy(previous, current)
+
+	_, err := io.WriteString(writer, prefix+strings.Join(lines, "\n")+"\n")
+	if err != nil {
+		return fmt.Errorf("write watch frame: %w", err)
+	}
+
+	return nil
+}
+
+func highlight(line string, enabled bool) string {
+	if !enabled {
+		return line
+	}
+
+	return text.Colors{text.Bold, text.FgYellow}.Sprint(line)
+}
@@ -0,0 +1,124 @@
+//nolint:testpackage // Command execution is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/repositorytest"
+	"github.com/stretchr/testify/require"
+)
+
+var errMonTimeout = errors.New("monclient: timed out")
+
+func TestClusterSnapshot_ChangesSince(t *testing.T) {
+	t.Parallel()
+
+	healthy := newClusterSnapshot(clusterStatusView{cluster: nil, status: &domain.CephStatus{
+		FSID:   "1",
+		Health: domain.Health{Status: domain.HealthWarn, Checks: []domain.HealthCheck{{Code: "POOL_FULL"}}},
+		OSDMap: domain.OSDMap{NumOSDs: 6, NumUpOSDs: 6},
+		PGMap:  domain.PGMap{NumPGs: 97, States: []domain.PGStateCount{{State: "active+clean", Count: 97}}},
+	}, err: nil})
+	degraded := newClusterSnapshot(clusterStatusView{cluster: nil, status: &domain.CephStatus{
+		FSID: "1",
+		Health: domain.Health{Status: domain.HealthErr, Checks: []domain.HealthCheck{
+			{Code: "OSD_DOWN", Message: "1 osds down"},
+		}},
+		OSDMap: domain.OSDMap{NumOSDs: 6, NumUpOSDs: 5},
+		PGMap: domain.PGMap{NumPGs: 97, States: []domain.PGStateCount{
+			{State: "active+clean", Count: 93}, {State: "active+undersized+degraded", Count: 4},
+		}},
+	}, err: nil})
+	failed := newClusterSnapshot(clusterStatusView{cluster: nil, status: nil, err: errMonTimeout})
+
+	require.Equal(t, []string{
+		"health: HEALTH_WARN -> HEALTH_ERR",
+		"new check OSD_DOWN: 1 osds down",
+		"cleared check POOL_FULL",
+		"osd up: 6/6 -> 5/6",
+		"pgs active+clean: 97 -> 93",
+		"pgs active+undersized+degraded: 0 -> 4",
+	}, degraded.changesSince(healthy))
+	require.Empty(t, degraded.changesSince(degraded))
+	require.Equal(t, []string{"error: monclient: timed out"}, failed.changesSince(healthy))
+	require.Equal(t, []string{"recovered from: monclient: timed out"}, healthy.changesSince(failed))
+	require.Equal(t,
+		"HEALTH_ERR  osd 5/6 up  pgs 97: 93 active+clean, 4 active+undersized+degraded  OSD_DOWN",
+		degraded.summary())
+}
+
+func TestWatchClusterStatus_HighlightsChangesUntilCancelled(t *testing.T) {
+	t.Parallel()
+
+	alpha, err := domain.NewCluster("alpha", "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	client := &sequenceCephClient{
+		statuses: []*domain.CephStatus{
+			{FSID: "1", Health: domain.Health{Status: domain.HealthOK, Checks: nil}},
+			{FSID: "1", Health: domain.Health{Status: domain.HealthWarn, Checks: []domain.HealthCheck{
+				{Code: "OSD_DOWN", Message: "1 osds down"},
+			}}},
+		},
+		calls:  0,
+		cancel: cancel,
+		mu:     sync.Mutex{},
+	}
+
+	var output bytes.Buffer
+
+	err = watchClusterStatus(ctx, &output, repositorytest.NewMemoryRepository(alpha), client, watchOptions{
+		selection: newTestSelection(t, nil, ""),
+		parallel:  1,
+		interval:  time.Millisecond,
+		terminal:  false,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, 2, strings.Count(output.String(), "cluster status"))
+	require.Contains(t, output.String(), "alpha  HEALTH_OK  osd 0/0 up")
+	require.Contains(t, output.String(), "alpha  HEALTH_WARN  osd 0/0 up  pgs 0:   OSD_DOWN\n"+
+		"  * health: HEALTH_OK -> HEALTH_WARN\n"+
+		"  * new check OSD_DOWN: 1 osds down\n")
+}
+
+func TestClusterStatusCmd_ValidatesWatchInterval(t *testing.T) {
+	t.Parallel()
+
+	err := parseCommandError(t, "cluster", "status", "--watch", "--interval", "10ms")
+	command := parseCommand(t, "cluster", "status", "-w")
+
+	require.ErrorIs(t, err, errInvalidInterval)
+	require.Equal(t, 10*time.Second, command.Cluster.Status.Interval)
+}
+
+// sequenceCephClient returns the next status on every call and, once they are used up, cancels
+// the watch during the following poll.
+type sequenceCephClient struct {
+	statuses []*domain.CephStatus
+	calls    int
+	cancel   context.CancelFunc
+	mu       sync.Mutex
+}
+
+func (s *sequenceCephClient) Status(context.Context, *domain.Cluster) (*domain.CephStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := s.statuses[min(s.calls, len(s.statuses)-1)]
+
+	s.calls++
+	if s.calls > len(s.statuses) {
+		s.cancel()
+	}
+
+	return status, nil
+}
@@ -0,0 +1,75 @@
+package cephdoctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+func (c *clusterUpdateCmd) Run(repo domain.ClusterRepository) error {
+	slog.Info("cluster update", "name", c.Name, "rename", c.Rename, "hosts", c.Hosts,
+		"add", c.AddHosts, "remove", c.RemoveHosts, "entity", c.Entity, "backend", c.Backend, "ssh", c.SSH,
+		"labels", c.Labels, "remove_labels", c.RemoveLabels, "reset_ceph_version", c.ResetVersion)
+
+	return c.run(context.Background(), repo, os.Stdin)
+}
+
+func (c *clusterUpdateCmd) run(ctx context.Context, repo domain.ClusterRepository, stdin io.Reader) error {
+	clusters, err := repo.ListClusters(ctx)
+	if err != nil {
+		return fmt.Errorf("list clusters: %w", err)
+	}
+
+	selected, err := selectClusterByName(clusters, c.Name)
+	if err != nil {
+		return err
+	}
+
+	updated, err := c.edit(selected[0], stdin)
+	if err != nil {
+		return err
+	}
+
+	err = repo.RenameCluster(ctx, c.Name, updated)
+	if err != nil {
+		return fmt.Errorf("update cluster: %w", err)
+	}
+
+	return nil
+}
+
+func (c *clusterUpdateCmd) edit(current *domain.Cluster, stdin io.Reader) (*domain.Cluster, error) {
+	hosts, err := c.hosts(current.Hosts())
+	if err != nil {
+		return nil, err
+	}
+
+	key := current.Key()
+	if c.KeyFile != "" {
+		key, err = readSecret(c.KeyFile, stdin)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	name := current.Name()
+	if c.Rename != "" {
+		name = c.Rename
+	}
+
+	opts, err := c.options(current)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := current.Edited(name, key, hosts, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("edit cluster: %w", err)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,82 @@
+package cephdoctor
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+var errConflictingHostFlags = errors.New("--host cannot be combined with --add-host or --remove-host")
+
+func (c *clusterUpdateCmd) hosts(current []string) ([]string, error) {
+	if len(c.Hosts) > 0 {
+		if len(c.AddHosts) > 0 || len(c.RemoveHosts) > 0 {
+			return nil, errConflictingHostFlags
+		}
+
+		return trimHostArgs(c.Hosts), nil
+	}
+
+	hosts, err := domain.EditHosts(current, trimHostArgs(c.AddHosts), c.RemoveHosts)
+	if err != nil {
+		return nil, fmt.Errorf("edit hosts: %w", err)
+	}
+
+	return hosts, nil
+}
+
+func (c *clusterUpdateCmd) options(current *domain.Cluster) ([]domain.ClusterOption, error) {
+	var opts []domain.ClusterOption
+
+	if len(c.Labels) > 0 || len(c.RemoveLabels) > 0 {
+		labels, err := c.labels(current.Labels())
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, domain.WithLabels(labels))
+	}
+
+	if c.Entity != "" {
+		opts = append(opts, domain.WithEntity(c.Entity))
+	}
+
+	if c.Backend != "" {
+		opts = append(opts, domain.WithBackend(domain.Backend(c.Backend)))
+	}
+
+	if c.SSH != "" {
+		target, err := domain.ParseSSHTarget(c.SSH)
+		if err != nil {
+			return nil, fmt.Errorf("parse ssh target: %w", err)
+		}
+
+		opts = append(opts, domain.WithSSHTarget(target))
+	}
+
+	if c.ResetVersion {
+		opts = append(opts, domain.WithCephVersion(""))
+	}
+
+	return opts, nil
+}
+
+// labels sets --label values over current and then drops the --remove-label keys.
+// Removing a key that is not set is not an error.
+func (c *clusterUpdateCmd) labels(current map[string]string) (map[string]string, error) {
+	set, err := domain.ParseLabels(c.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("parse labels: %w", err)
+	}
+
+	maps.Copy(current, set)
+
+	for _, key := range c.RemoveLabels {
+		delete(current, strings.TrimSpace(key))
+	}
+
+	return current, nil
+}
@@ -0,0 +1,156 @@
+//nolint:testpackage // Command execution is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/repositorytest"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/fscluster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterUpdateCmd_EditsHostsAndRotatesKeyFromStdin(t *testing.T) {
+	t.Parallel()
+
+	repo := newRegisteredRepository(t)
+	command := parseCommand(t, "cluster", "update", "alpha",
+		"--add-host", "10.0.0.3", "--remove-host", "10.0.0.1", "--key-file", "-")
+
+	err := command.Cluster.Update.run(t.Context(), repo, strings.NewReader("rotated\n"))
+
+	require.NoError(t, err)
+
+	cluster := requireSingleCluster(t, repo)
+	require.Equal(t, "alpha", cluster.Name())
+	require.Equal(t, "rotated", cluster.Key())
+	require.Equal(t, []string{"10.0.0.2:3300", "10.0.0.3:3300"}, cluster.Hosts())
+	require.Equal(t, domain.BackendLocal, cluster.Backend())
+}
+
+func TestClusterUpdateCmd_RenamesAndReplacesHosts(t *testing.T) {
+	t.Parallel()
+
+	repo := newRegisteredRepository(t)
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("from-file\n"), 0o600))
+	command := parseCommand(t, "cluster", "update", "alpha",
+		"--rename", "beta", "--host", "10.1.0.1:6789", "--key-file", keyFile)
+
+	err := command.Cluster.Update.run(t.Context(), repo, strings.NewReader(""))
+
+	require.NoError(t, err)
+
+	cluster := requireSingleCluster(t, repo)
+	require.Equal(t, "beta", cluster.Name())
+	require.Equal(t, "from-file", cluster.Key())
+	require.Equal(t, []string{"10.1.0.1:6789"}, cluster.Hosts())
+}
+
+func TestClusterUpdateCmd_SetsAndRemovesLabels(t *testing.T) {
+	t.Parallel()
+
+	repo := repositorytest.NewMemoryRepository()
+	register := parseCommand(t, "cluster", "register", "alpha", "secret", "--host", "10.0.0.1",
+		"--label", "env=dev,dc=seoul", "--label", "canary=true")
+	require.NoError(t, register.Cluster.Register.Run(repo))
+
+	command := parseCommand(t, "cluster", "update", "alpha", "--label", "env=prod", "--remove-label", "canary,missing")
+
+	err := command.Cluster.Update.run(t.Context(), repo, strings.NewReader(""))
+
+	require.NoError(t, err)
+
+	clusters, err := repo.ListClusters(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"env": "prod", "dc": "seoul"}, clusters[0].Labels())
+}
+
+func TestClusterUpdateCmd_RejectsInvalidEdits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+		err  error
+	}{
+		{name: "unknown cluster", args: []string{"missing", "--rename", "beta"}, err: domain.ErrClusterNotFound},
+		{name: "invalid label", args: []string{"alpha", "--label", "env"}, err: domain.ErrInvalidLabel},
+		{name: "unknown host", args: []string{"alpha", "--remove-host", "10.9.9.9"}, err: domain.ErrHostNotFound},
+		{name: "no hosts left", args: []string{"alpha", "--remove-host", "10.0.0.1,10.0.0.2"}, err: domain.ErrEmptyHosts},
+		{name: "duplicate host", args: []string{"alpha", "--add-host", "10.0.0.1:3300"}, err: domain.ErrDuplicateHost},
+		{name: "conflicting flags", args: []string{"alpha", "--host", "10.0.0.5", "--add-host", "10.0.0.6"}, err: errConflictingHostFlags},
+		{name: "empty key", args: []string{"alpha", "--key-file", "-"}, err: domain.ErrEmptyClusterKey},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := newRegisteredRepository(t)
+			command := parseCommand(t, append([]string{"cluster", "update"}, test.args...)...)
+
+			err := command.Cluster.Update.run(t.Context(), repo, strings.NewReader("\n"))
+
+			require.ErrorIs(t, err, test.err)
+			require.Equal(t, "secret", requireSingleCluster(t, repo).Key())
+		})
+	}
+}
+
+func newRegisteredRepository(t *testing.T) *fscluster.Repository {
+	t.Helper()
+
+	return newRegisteredRepositoryIn(t, t.TempDir())
+}
+
+func newRegisteredRepositoryIn(t *testing.T, root string) *fscluster.Repository {
+	t.Helper()
+
+	repo, err := fscluster.NewRepository(root)
+	require.NoError(t, err)
+
+	cluster, err := domain.NewCluster("alpha", "secret", []string{"10.0.0.1", "10.0.0.2"},
+		domain.WithBackend(domain.BackendLocal))
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateCluster(t.Context(), cluster))
+
+	return repo
+}
+
+func newEmptyRepository(t *testing.T) *fscluster.Repository {
+	t.Helper()
+
+	repo, err := fscluster.NewRepository(t.TempDir())
+	require.NoError(t, err)
+
+	return repo
+}
+
+func TestClusterUpdateCmd_ResetsCephVersion(t *testing.T) {
+	t.Parallel()
+
+	alpha, err := domain.NewCluster("alpha", "secret", []string{"10.0.0.1"}, domain.WithCephVersion("17.2.8"))
+	require.NoError(t, err)
+
+	repo := repositorytest.NewMemoryRepository(alpha)
+	command := parseCommand(t, "cluster", "update", "alpha", "--reset-ceph-version")
+
+	err = command.Cluster.Update.run(t.Context(), repo, strings.NewReader(""))
+
+	require.NoError(t, err)
+	require.Empty(t, requireSingleCluster(t, repo).CephVersion())
+}
+
+func requireSingleCluster(t *testing.T, repo domain.ClusterRepository) *domain.Cluster {
+	t.Helper()
+
+	clusters, err := repo.ListClusters(t.Context())
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+
+	return clusters[0]
+}
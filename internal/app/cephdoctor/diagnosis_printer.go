@@ -0,0 +1,91 @@
+package cephdoctor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/diagnosis"
+)
+
+func renderDiagnoses(writer io.Writer, output outputFormat, diagnoses []clusterDiagnosis) error {
+	if output != outputTable {
+		items := make([]clusterDiagnosisItem, 0, len(diagnoses))
+		for _, result := range diagnoses {
+			items = append(items, newClusterDiagnosisItem(result.clusterStatusView, result.findings))
+		}
+
+		return writeDocument(writer, output, clusterDiagnosisKind, items)
+	}
+
+	for i, result := range diagnoses {
+		err := renderDiagnosis(writer, i, result.cluster, result.findings, result.err)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renderDiagnosis(
+	writer io.Writer,
+	index int,
+	cluster *domain.Cluster,
+	findings []diagnosis.Finding,
+	statusErr error,
+) error {
+	err := writeStatusHeader(writer, index, cluster)
+	if err != nil {
+		return err
+	}
+
+	if statusErr != nil {
+		_, err = fmt.Fprintf(writer, "[error] %v\n", statusErr)
+		if err != nil {
+			return fmt.Errorf("write diagnosis error: %w", err)
+		}
+
+		return nil
+	}
+
+	if len(findings) == 0 {
+		_, err = fmt.Fprintln(writer, "No findings.")
+		if err != nil {
+			return fmt.Errorf("write empty diagnosis: %w", err)
+		}
+
+		return nil
+	}
+
+	for _, finding := range findings {
+		err = writeFinding(writer, finding)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFinding(writer io.Writer, finding diagnosis.Finding) error {
+	lines := []string{
+		fmt.Sprintf("[%s] %s: %s", strings.ToUpper(string(finding.Severity)), finding.RuleID, finding.Summary),
+	}
+
+	for _, evidence := range finding.Evidence {
+		lines = append(lines, "  evidence: "+evidence)
+	}
+
+	if finding.Remediation != "" {
+		lines = append(lines, "  remediation: "+finding.Remediation)
+	}
+
+	_, err := io.WriteString(writer, strings.Join(lines, "\n")+"\n")
+	if err != nil {
+		return fmt.Errorf("write finding: %w", err)
+	}
+
+	return nil
+}
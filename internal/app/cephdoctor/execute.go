@@ -1,6 +1,7 @@
 package cephdoctor
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -8,19 +9,17 @@ import (
 
 	"github.com/alecthomas/kong"
 	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/appconfig"
 	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephpodman"
-	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/fscluster"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/secretref"
 )
 
-func Execute() error {
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, nil)))
-
-	repo, err := fscluster.NewRepository("")
-	if err != nil {
-		return fmt.Errorf("new repository: %w", err)
-	}
+// passphraseEnv names the variable holding the passphrase that seals stored cluster keys.
+// Without it the repository falls back to its master.key file, if any.
+const passphraseEnv = "CEPHDOCTOR_PASSPHRASE"
 
-	cephClient := cephpodman.NewCephClient()
+func Execute() error {
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
 
 	var command cli
 
@@ -28,8 +27,6 @@ func Execute() error {
 		&command,
 		kong.Name("cephdoctor"),
 		kong.Description("Ceph Doctor CLI"),
-		kong.BindTo(repo, (*domain.ClusterRepository)(nil)),
-		kong.BindTo(cephClient, (*domain.CephClient)(nil)),
 	)
 	if err != nil {
 		return fmt.Errorf("create parser: %w", err)
@@ -45,10 +42,48 @@ func Execute() error {
 		return fmt.Errorf("parse args: %w", err)
 	}
 
-	err = ctx.Run()
+	backend, err := domain.ParseBackend(command.Backend)
+	if err != nil {
+		return fmt.Errorf("parse backend: %w", err)
+	}
+
+	config, err := appconfig.Load(command.Config)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	backendRepo, err := openRepository(repositoryKind(command.Repository, config))
+	if err != nil {
+		return err
+	}
+	defer closeRepository(backendRepo)
+
+	router, podmanClient := newCephClientRouterFor(&command, backend, config)
+	defer closeCephClient(podmanClient)
+
+	resolver := secretref.NewResolver()
+	cephClient := newCephVersionRecorder(secretref.NewCephClient(router, resolver), backendRepo.repo)
+
+	ctx.BindTo(newTolerantRepository(backendRepo.repo, os.Stderr), (*domain.ClusterRepository)(nil))
+	ctx.BindTo(backendRepo.maintainer, (*keyEncrypter)(nil))
+	ctx.BindTo(backendRepo.maintainer, (*fileMigrator)(nil))
+	ctx.BindTo(backendRepo.maintainer, (*repositoryDoctor)(nil))
+	ctx.BindTo(cephClient, (*domain.CephClient)(nil))
+	ctx.BindTo(secretref.NewCephExecutor(router, resolver), (*domain.CephExecutor)(nil))
+	ctx.BindTo(podmanClient, (*cephShell)(nil))
+	ctx.BindTo(resolver, (*domain.KeyResolver)(nil))
+
+	err = ctx.Run(command.Output)
 	if err != nil {
 		return fmt.Errorf("run command: %w", err)
 	}
 
 	return nil
 }
+
+func closeCephClient(cephClient *cephpodman.CephClient) {
+	err := cephClient.Close(context.Background())
+	if err != nil {
+		slog.Warn("close ceph client", "error", err)
+	}
+}
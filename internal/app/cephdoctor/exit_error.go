@@ -0,0 +1,13 @@
+package cephdoctor
+
+import "fmt"
+
+// ExitError reports that a command run on behalf of the user exited with a non-zero code.
+// Its output has already been shown, so the caller only needs to exit with Code.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("command exited with status %d", e.Code)
+}
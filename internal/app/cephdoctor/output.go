@@ -0,0 +1,81 @@
+package cephdoctor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+type outputFormat string
+
+const (
+	outputTable  outputFormat = "table"
+	outputJSON   outputFormat = "json"
+	outputYAML   outputFormat = "yaml"
+	outputNDJSON outputFormat = "ndjson"
+)
+
+// outputSchemaVersion identifies the layout of machine-readable output.
+// Bump it whenever a field is removed or changes meaning.
+const outputSchemaVersion = "cephdoctor/v1"
+
+var errUnsupportedOutput = errors.New("unsupported output format")
+
+// outputDocument wraps every item of a json or yaml response.
+type outputDocument[T any] struct {
+	SchemaVersion string `json:"schemaVersion" yaml:"schemaVersion"`
+	Kind          string `json:"kind"          yaml:"kind"`
+	Items         []T    `json:"items"         yaml:"items"`
+}
+
+// outputRecord is a single ndjson line.
+type outputRecord[T any] struct {
+	SchemaVersion string `json:"schemaVersion"`
+	Kind          string `json:"kind"`
+	Item          T      `json:"item"`
+}
+
+// writeDocument renders items in a machine-readable format.
+// kind names a single item; json and yaml documents use kind + "List".
+func writeDocument[T any](writer io.Writer, format outputFormat, kind string, items []T) error {
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+
+		return encodeOutput(encoder.Encode, newOutputDocument(kind, items))
+	case outputYAML:
+		return encodeYAML(writer, newOutputDocument(kind, items))
+	case outputNDJSON:
+		encoder := json.NewEncoder(writer)
+		for _, item := range items {
+			err := encodeOutput(encoder.Encode, outputRecord[T]{
+				SchemaVersion: outputSchemaVersion,
+				Kind:          kind,
+				Item:          item,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case outputTable:
+		return fmt.Errorf("%w: %s", errUnsupportedOutput, format)
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedOutput, format)
+	}
+}
+
+func newOutputDocument[T any](kind string, items []T) outputDocument[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	return outputDocument[T]{
+		SchemaVersion: outputSchemaVersion,
+		Kind:          kind + "List",
+		Items:         items,
+	}
+}
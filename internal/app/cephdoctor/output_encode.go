@@ -0,0 +1,35 @@
+package cephdoctor
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+func encodeOutput(encode func(any) error, value any) error {
+	err := encode(value)
+	if err != nil {
+		return fmt.Errorf("encode output: %w", err)
+	}
+
+	return nil
+}
+
+func encodeYAML(writer io.Writer, value any) error {
+	encoder := yaml.NewEncoder(writer)
+
+	err := encodeOutput(encoder.Encode, value)
+	if err != nil {
+		_ = encoder.Close()
+
+		return err
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return fmt.Errorf("close yaml encoder: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package cephdoctor
+
+import (
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/diagnosis"
+)
+
+const (
+	clusterKind          = "Cluster"
+	clusterStatusKind    = "ClusterStatus"
+	clusterDiagnosisKind = "ClusterDiagnosis"
+)
+
+type clusterItem struct {
+	Name   string            `json:"name"             yaml:"name"`
+	Hosts  []string          `json:"hosts"            yaml:"hosts"`
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
+	CephVersion string `json:"cephVersion,omitempty" yaml:"cephVersion,omitempty"`
+}
+
+type clusterStatusItem struct {
+	clusterItem `yaml:",inline"`
+
+	Status *statusItem `json:"status,omitempty" yaml:"status,omitempty"`
+	Error  string      `json:"error,omitempty"  yaml:"error,omitempty"`
+}
+
+type clusterDiagnosisItem struct {
+	clusterItem `yaml:",inline"`
+
+	Findings []findingItem `json:"findings"        yaml:"findings"`
+	Error    string        `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type findingItem struct {
+	RuleID      string   `json:"ruleId"      yaml:"ruleId"`
+	Severity    string   `json:"severity"    yaml:"severity"`
+	Summary     string   `json:"summary"     yaml:"summary"`
+	Evidence    []string `json:"evidence"    yaml:"evidence"`
+	Remediation string   `json:"remediation" yaml:"remediation"`
+}
+
+func newClusterItem(cluster *domain.Cluster) clusterItem {
+	return clusterItem{
+		Name:   cluster.Name(),
+		Hosts:  cluster.Hosts(),
+		Labels: cluster.Labels(),
+
+		CephVersion: cluster.CephVersion(),
+	}
+}
+
+func newClusterStatusItem(result clusterStatusView) clusterStatusItem {
+	item := clusterStatusItem{
+		clusterItem: newClusterItem(result.cluster),
+		Status:      nil,
+		Error:       "",
+	}
+
+	if result.status != nil && result.status.FSID != "" {
+		item.Status = newStatusItem(result.status)
+	}
+
+	if result.err != nil {
+		item.Error = result.err.Error()
+	}
+
+	return item
+}
+
+func newClusterDiagnosisItem(result clusterStatusView, findings []diagnosis.Finding) clusterDiagnosisItem {
+	item := clusterDiagnosisItem{
+		clusterItem: newClusterItem(result.cluster),
+		Findings:    make([]findingItem, 0, len(findings)),
+		Error:       "",
+	}
+
+	for _, finding := range findings {
+		item.Findings = append(item.Findings, findingItem{
+			RuleID:      finding.RuleID,
+			Severity:    string(finding.Severity),
+			Summary:     finding.Summary,
+			Evidence:    append([]string{}, finding.Evidence...),
+			Remediation: finding.Remediation,
+		})
+	}
+
+	if result.err != nil {
+		item.Error = result.err.Error()
+	}
+
+	return item
+}
@@ -0,0 +1,94 @@
+package cephdoctor
+
+import "github.com/neatflowcv/ceph-doctor/internal/domain"
+
+type statusItem struct {
+	FSID   string     `json:"fsid"   yaml:"fsid"`
+	Health healthItem `json:"health" yaml:"health"`
+	Mon    monItem    `json:"mon"    yaml:"mon"`
+	Mgr    mgrItem    `json:"mgr"    yaml:"mgr"`
+	OSD    osdItem    `json:"osd"    yaml:"osd"`
+	PG     pgItem     `json:"pg"     yaml:"pg"`
+	Usage  usageItem  `json:"usage"  yaml:"usage"`
+	IO     ioItem     `json:"io"     yaml:"io"`
+}
+
+type healthItem struct {
+	Status string            `json:"status" yaml:"status"`
+	Checks []healthCheckItem `json:"checks" yaml:"checks"`
+}
+
+type healthCheckItem struct {
+	Code     string `json:"code"     yaml:"code"`
+	Severity string `json:"severity" yaml:"severity"`
+	Message  string `json:"message"  yaml:"message"`
+	Count    int    `json:"count"    yaml:"count"`
+	Muted    bool   `json:"muted"    yaml:"muted"`
+}
+
+type monItem struct {
+	Count  int      `json:"count"  yaml:"count"`
+	Quorum []string `json:"quorum" yaml:"quorum"`
+}
+
+type mgrItem struct {
+	Available bool `json:"available" yaml:"available"`
+	Standbys  int  `json:"standbys"  yaml:"standbys"`
+}
+
+type osdItem struct {
+	Total int `json:"total" yaml:"total"`
+	Up    int `json:"up"    yaml:"up"`
+	In    int `json:"in"    yaml:"in"`
+}
+
+type pgItem struct {
+	Total  int            `json:"total"  yaml:"total"`
+	States map[string]int `json:"states" yaml:"states"`
+}
+
+type usageItem struct {
+	UsedBytes  uint64 `json:"usedBytes"  yaml:"usedBytes"`
+	AvailBytes uint64 `json:"availBytes" yaml:"availBytes"`
+	TotalBytes uint64 `json:"totalBytes" yaml:"totalBytes"`
+}
+
+type ioItem struct {
+	ReadBytesPerSec  int64 `json:"readBytesPerSec"  yaml:"readBytesPerSec"`
+	WriteBytesPerSec int64 `json:"writeBytesPerSec" yaml:"writeBytesPerSec"`
+	ReadOpsPerSec    int64 `json:"readOpsPerSec"    yaml:"readOpsPerSec"`
+	WriteOpsPerSec   int64 `json:"writeOpsPerSec"   yaml:"writeOpsPerSec"`
+}
+
+func newStatusItem(status *domain.CephStatus) *statusItem {
+	checks := make([]healthCheckItem, 0, len(status.Health.Checks))
+	for _, check := range status.Health.Checks {
+		checks = append(checks, healthCheckItem{
+			Code:     check.Code,
+			Severity: string(check.Severity),
+			Message:  check.Message,
+			Count:    check.Count,
+			Muted:    check.Muted,
+		})
+	}
+
+	states := make(map[string]int, len(status.PGMap.States))
+	for _, state := range status.PGMap.States {
+		states[state.State] = state.Count
+	}
+
+	return &statusItem{
+		FSID:   status.FSID,
+		Health: healthItem{Status: string(status.Health.Status), Checks: checks},
+		Mon:    monItem{Count: status.MonMap.NumMons, Quorum: append([]string{}, status.MonMap.QuorumNames...)},
+		Mgr:    mgrItem{Available: status.MgrMap.Available, Standbys: status.MgrMap.NumStandbys},
+		OSD:    osdItem{Total: status.OSDMap.NumOSDs, Up: status.OSDMap.NumUpOSDs, In: status.OSDMap.NumInOSDs},
+		PG:     pgItem{Total: status.PGMap.NumPGs, States: states},
+		Usage: usageItem{
+			UsedBytes:  status.Usage.UsedBytes,
+			AvailBytes: status.Usage.AvailBytes,
+			TotalBytes: status.Usage.TotalBytes,
+		},
+		IO: ioItem(status.IO),
+	}
+}
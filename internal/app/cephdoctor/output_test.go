@@ -0,0 +1,121 @@
+//nolint:testpackage // Command rendering is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/repositorytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunClusterStatus_JSONReportsErrorsAsData(t *testing.T) {
+	t.Parallel()
+
+	alpha, err := domain.NewCluster("alpha", "secret-a", []string{"10.0.0.2"})
+	require.NoError(t, err)
+
+	zeta, err := domain.NewCluster("zeta", "secret-z", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	healthy := new(domain.CephStatus)
+	healthy.FSID = "fsid-a"
+	healthy.Health.Status = domain.HealthOK
+
+	repo := repositorytest.NewMemoryRepository(alpha, zeta)
+	cephClient := &fakeCephClient{
+		statuses: map[*domain.Cluster]*domain.CephStatus{alpha: healthy},
+		errs:     map[*domain.Cluster]error{zeta: errExecFailed},
+		called:   false,
+		clusters: nil,
+		mu:       sync.Mutex{},
+	}
+
+	var output bytes.Buffer
+
+	err = runClusterStatus(t.Context(), &output, repo, cephClient, newTestSelection(t, nil, ""), 1, outputJSON)
+
+	require.ErrorIs(t, err, errClusterStatusFailed)
+	require.JSONEq(t, `{
+		"schemaVersion": "cephdoctor/v1",
+		"kind": "ClusterStatusList",
+		"items": [
+			{
+				"name": "alpha",
+				"hosts": ["10.0.0.2:3300"],
+				"status": {
+					"fsid": "fsid-a",
+					"health": {"status": "HEALTH_OK", "checks": []},
+					"mon": {"count": 0, "quorum": []},
+					"mgr": {"available": false, "standbys": 0},
+					"osd": {"total": 0, "up": 0, "in": 0},
+					"pg": {"total": 0, "states": {}},
+					"usage": {"usedBytes": 0, "availBytes": 0, "totalBytes": 0},
+					"io": {"readBytesPerSec": 0, "writeBytesPerSec": 0, "readOpsPerSec": 0, "writeOpsPerSec": 0}
+				}
+			},
+			{
+				"name": "zeta",
+				"hosts": ["10.0.0.1:3300"],
+				"error": "exec failed"
+			}
+		]
+	}`, output.String())
+}
+
+func TestRenderClusters_NDJSONWritesOneRecordPerLine(t *testing.T) {
+	t.Parallel()
+
+	alpha, err := domain.NewCluster("alpha", "secret-a", []string{"10.0.0.2"})
+	require.NoError(t, err)
+
+	zeta, err := domain.NewCluster("zeta", "secret-z", []string{"10.0.0.1", "10.0.0.3"})
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+
+	err = renderClusters(&output, outputNDJSON, []*domain.Cluster{alpha, zeta})
+
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`{"schemaVersion":"cephdoctor/v1","kind":"Cluster","item":{"name":"alpha","hosts":["10.0.0.2:3300"]}}`+"\n"+
+			`{"schemaVersion":"cephdoctor/v1","kind":"Cluster","item":`+
+			`{"name":"zeta","hosts":["10.0.0.1:3300","10.0.0.3:3300"]}}`+"\n",
+		output.String(),
+	)
+}
+
+func TestRenderClusters_YAMLDocument(t *testing.T) {
+	t.Parallel()
+
+	alpha, err := domain.NewCluster("alpha", "secret-a", []string{"10.0.0.2"})
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+
+	err = renderClusters(&output, outputYAML, []*domain.Cluster{alpha})
+
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"schemaVersion: cephdoctor/v1\nkind: ClusterList\nitems:\n    - name: alpha\n      hosts:\n        - 10.0.0.2:3300\n",
+		output.String(),
+	)
+}
+
+func TestRunClusterStatus_JSONEmptyRepository(t *testing.T) {
+	t.Parallel()
+
+	repo := repositorytest.NewMemoryRepository()
+	cephClient := &fakeCephClient{statuses: nil, errs: nil, called: false, clusters: nil, mu: sync.Mutex{}}
+
+	var output bytes.Buffer
+
+	err := runClusterStatus(t.Context(), &output, repo, cephClient, newTestSelection(t, nil, ""), 1, outputJSON)
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"schemaVersion":"cephdoctor/v1","kind":"ClusterStatusList","items":[]}`, output.String())
+}
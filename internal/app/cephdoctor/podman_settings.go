@@ -0,0 +1,23 @@
+package cephdoctor
+
+import (
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/appconfig"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephpodman"
+)
+
+// podmanSettings applies the config file's image and timeouts to each cluster's podman container.
+// The image follows the cluster's cached release through the config's images map, and
+// defaults.image only applies when neither the map nor the built-in release images match.
+func podmanSettings(config *appconfig.Config) func(cluster *domain.Cluster) cephpodman.Settings {
+	return func(cluster *domain.Cluster) cephpodman.Settings {
+		settings := config.For(cluster.Name())
+
+		return cephpodman.Settings{
+			Image:          config.ImageFor(cluster.Name(), cluster.CephVersion()),
+			DefaultImage:   config.Defaults.Image,
+			CommandTimeout: settings.CommandTimeout,
+			CleanupTimeout: settings.CleanupTimeout,
+		}
+	}
+}
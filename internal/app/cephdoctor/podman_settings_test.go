@@ -0,0 +1,46 @@
+//nolint:testpackage // Command execution is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/appconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPodmanSettings_LeavesReleaseImagesAheadOfDefaultImage(t *testing.T) {
+	t.Parallel()
+
+	config := &appconfig.Config{
+		Repository: "",
+		Defaults: appconfig.ClusterConfig{
+			Image: "mirror.local/ceph/ceph:v18.2.7", CommandTimeout: time.Minute, CleanupTimeout: 0, Backend: "",
+		},
+		Clusters: map[string]appconfig.ClusterConfig{
+			"pinned": {Image: "mirror.local/ceph/ceph:v19.2.3", CommandTimeout: 0, CleanupTimeout: 0, Backend: ""},
+		},
+		Images: map[string]string{"17": "mirror.local/ceph/ceph:v17"},
+	}
+	settings := podmanSettings(config)
+
+	pinned := settings(newVersionedCluster(t, "pinned", "17.2.8"))
+	mapped := settings(newVersionedCluster(t, "alpha", "17.2.8"))
+	unmapped := settings(newVersionedCluster(t, "beta", "16.2.15"))
+
+	require.Equal(t, "mirror.local/ceph/ceph:v19.2.3", pinned.Image)
+	require.Equal(t, "mirror.local/ceph/ceph:v17", mapped.Image)
+	require.Empty(t, unmapped.Image, "the backend's release images must get a chance before defaults.image")
+	require.Equal(t, "mirror.local/ceph/ceph:v18.2.7", unmapped.DefaultImage)
+	require.Equal(t, time.Minute, unmapped.CommandTimeout)
+}
+
+func newVersionedCluster(t *testing.T, name, version string) *domain.Cluster {
+	t.Helper()
+
+	cluster, err := domain.NewCluster(name, "secret", []string{"10.0.0.1"}, domain.WithCephVersion(version))
+	require.NoError(t, err)
+
+	return cluster
+}
@@ -0,0 +1,89 @@
+package cephdoctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+var errRepositoryProblems = errors.New("cluster repository has unreadable files")
+
+// repositoryDoctor inspects and fixes the files behind a cluster repository.
+type repositoryDoctor interface {
+	domain.TolerantClusterLister
+	RepairFiles(ctx context.Context) ([]string, error)
+	QuarantineFiles(ctx context.Context) ([]string, error)
+}
+
+func (c *repoDoctorCmd) Run(doctor repositoryDoctor) error {
+	slog.Info("repo doctor", "repair", c.Repair, "quarantine", c.Quarantine)
+
+	return c.run(context.Background(), os.Stdout, doctor)
+}
+
+func (c *repoDoctorCmd) run(ctx context.Context, writer io.Writer, doctor repositoryDoctor) error {
+	var actions []string
+
+	if c.Repair {
+		repaired, err := doctor.RepairFiles(ctx)
+		actions = append(actions, repaired...)
+
+		if err != nil {
+			return fmt.Errorf("repair cluster files: %w", err)
+		}
+	}
+
+	if c.Quarantine {
+		quarantined, err := doctor.QuarantineFiles(ctx)
+		actions = append(actions, quarantined...)
+
+		if err != nil {
+			return fmt.Errorf("quarantine cluster files: %w", err)
+		}
+	}
+
+	_, problems, err := doctor.ListClustersTolerant(ctx)
+	if err != nil {
+		return fmt.Errorf("list clusters: %w", err)
+	}
+
+	err = writeDoctorReport(writer, actions, problems)
+	if err != nil {
+		return err
+	}
+
+	if len(problems) > 0 {
+		return errRepositoryProblems
+	}
+
+	return nil
+}
+
+func writeDoctorReport(writer io.Writer, actions []string, problems []domain.LoadProblem) error {
+	lines := make([]string, 0, len(actions)+len(problems)+1)
+	for _, action := range actions {
+		lines = append(lines, "Fixed: "+action)
+	}
+
+	for _, problem := range problems {
+		lines = append(lines, fmt.Sprintf("Problem: %s: %v", problem.Source, problem.Err))
+	}
+
+	if len(problems) == 0 {
+		lines = append(lines, "No problems found.")
+	}
+
+	for _, line := range lines {
+		_, err := fmt.Fprintln(writer, line)
+		if err != nil {
+			return fmt.Errorf("write doctor report: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package cephdoctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// keyEncrypter seals the plaintext keys left in a cluster repository.
+type keyEncrypter interface {
+	EncryptKeys(ctx context.Context) (int, error)
+}
+
+func (c *repoEncryptCmd) Run(encrypter keyEncrypter) error {
+	slog.Info("repo encrypt")
+
+	return runRepoEncrypt(context.Background(), os.Stdout, encrypter)
+}
+
+func runRepoEncrypt(ctx context.Context, writer io.Writer, encrypter keyEncrypter) error {
+	encrypted, err := encrypter.EncryptKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("encrypt cluster keys: %w", err)
+	}
+
+	_, err = fmt.Fprintf(writer, "Encrypted %d cluster keys.\n", encrypted)
+	if err != nil {
+		return fmt.Errorf("write encrypt result: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package cephdoctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// fileMigrator upgrades stored cluster files to the current schema version, skipping and
+// reporting the files it cannot read.
+type fileMigrator interface {
+	MigrateFiles(ctx context.Context) (int, []domain.LoadProblem, error)
+}
+
+func (c *repoMigrateCmd) Run(migrator fileMigrator) error {
+	slog.Info("repo migrate")
+
+	return runRepoMigrate(context.Background(), os.Stdout, migrator)
+}
+
+func runRepoMigrate(ctx context.Context, writer io.Writer, migrator fileMigrator) error {
+	migrated, problems, err := migrator.MigrateFiles(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate cluster files: %w", err)
+	}
+
+	_, err = fmt.Fprintf(writer, "Migrated %d cluster files.\n", migrated)
+	if err != nil {
+		return fmt.Errorf("write migrate result: %w", err)
+	}
+
+	for _, problem := range problems {
+		_, err = fmt.Fprintf(writer, "Skipped: %s: %v\n", problem.Source, problem.Err)
+		if err != nil {
+			return fmt.Errorf("write migrate result: %w", err)
+		}
+	}
+
+	if len(problems) > 0 {
+		return errRepositoryProblems
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+//nolint:testpackage // Command execution is tested through unexported helpers.
+package cephdoctor
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/appconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunRepoEncrypt_SealsRegisteredClusters(t *testing.T) {
+	t.Parallel()
+
+	repo := newRegisteredRepository(t)
+
+	var output bytes.Buffer
+
+	err := runRepoEncrypt(t.Context(), &output, repo)
+
+	require.NoError(t, err)
+	require.Equal(t, "Encrypted 1 cluster keys.\n", output.String())
+	require.True(t, repo.Encrypted())
+	require.Equal(t, "secret", requireSingleCluster(t, repo).Key())
+}
+
+func TestRunRepoMigrate_ReportsRewrittenFiles(t *testing.T) {
+	t.Parallel()
+
+	repo := newRegisteredRepository(t)
+
+	var output bytes.Buffer
+
+	err := runRepoMigrate(t.Context(), &output, repo)
+
+	require.NoError(t, err)
+	require.Equal(t, "Migrated 0 cluster files.\n", output.String())
+}
+
+func TestRunRepoMigrate_ReportsSkippedFiles(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	repo := newRegisteredRepositoryIn(t, root)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "clusters", "broken.json"), []byte("{"), 0o600))
+
+	var output bytes.Buffer
+
+	err := runRepoMigrate(t.Context(), &output, repo)
+
+	require.ErrorIs(t, err, errRepositoryProblems)
+	require.Contains(t, output.String(), "Migrated 0 cluster files.\n")
+	require.Contains(t, output.String(), "Skipped: "+filepath.Join(root, "clusters", "broken.json"))
+}
+
+func TestRepoDoctorCmd_QuarantinesBrokenFiles(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	repo := newRegisteredRepositoryIn(t, root)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "clusters", "broken.json"), []byte("{"), 0o600))
+
+	var report bytes.Buffer
+
+	reportErr := parseCommand(t, "repo", "doctor").Repo.Doctor.run(t.Context(), &report, repo)
+
+	var fixed bytes.Buffer
+
+	fixErr := parseCommand(t, "repo", "doctor", "--quarantine").Repo.Doctor.run(t.Context(), &fixed, repo)
+
+	require.ErrorIs(t, reportErr, errRepositoryProblems)
+	require.Contains(t, report.String(), "Problem: "+filepath.Join(root, "clusters", "broken.json"))
+	require.NoError(t, fixErr)
+	require.Contains(t, fixed.String(), "Fixed: quarantined")
+	require.Contains(t, fixed.String(), "No problems found.")
+}
+
+func TestTolerantRepository_SkipsBrokenFiles(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	repo := newRegisteredRepositoryIn(t, root)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "clusters", "broken.json"), []byte("{"), 0o600))
+
+	var warnings bytes.Buffer
+
+	clusters, err := newTolerantRepository(repo, &warnings).ListClusters(t.Context())
+
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+	require.Equal(t, "alpha", clusters[0].Name())
+	require.Equal(t, "Skipped 1 unreadable cluster records; run repo doctor.\n", warnings.String())
+}
+
+func TestTolerantRepository_FailsWhenEveryFileIsUnreadable(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	repo := newRegisteredRepositoryIn(t, root)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "clusters", "alpha.json"), []byte("{"), 0o600))
+
+	var warnings bytes.Buffer
+
+	clusters, err := newTolerantRepository(repo, &warnings).ListClusters(t.Context())
+
+	require.ErrorIs(t, err, errNoReadableClusters)
+	require.ErrorContains(t, err, "1 skipped")
+	require.Nil(t, clusters)
+}
+
+func TestOpenRepository_SQLiteRejectsFileMaintenance(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Setenv(passphraseEnv, "")
+
+	backend, err := openRepository(repositorySQLite)
+	require.NoError(t, err)
+
+	defer closeRepository(backend)
+
+	command := repoDoctorCmd{Repair: true, Quarantine: false}
+	err = command.run(t.Context(), &bytes.Buffer{}, backend.maintainer)
+
+	require.ErrorIs(t, err, errRepositoryCommandUnsupported)
+	require.FileExists(t, filepath.Join(os.Getenv("XDG_STATE_HOME"), "ceph-doctor", "clusters.db"))
+}
+
+func TestRepositoryKind_PrefersFlagThenEnvThenConfig(t *testing.T) {
+	configured := &appconfig.Config{Repository: "sqlite", Defaults: appconfig.ClusterConfig{}, Clusters: nil, Images: nil}
+	unset := &appconfig.Config{Repository: "", Defaults: appconfig.ClusterConfig{}, Clusters: nil, Images: nil}
+
+	t.Setenv("CEPHDOCTOR_REPOSITORY", "")
+
+	fromConfig := parseCommand(t, "cluster", "list").Repository
+
+	t.Setenv("CEPHDOCTOR_REPOSITORY", "file")
+
+	fromEnv := parseCommand(t, "cluster", "list").Repository
+	fromFlag := parseCommand(t, "--repository", "sqlite", "cluster", "list").Repository
+
+	require.Equal(t, repositorySQLite, repositoryKind(fromConfig, configured))
+	require.Equal(t, repositoryFile, repositoryKind(fromConfig, unset))
+	require.Equal(t, repositoryFile, repositoryKind(fromEnv, configured))
+	require.Equal(t, repositorySQLite, repositoryKind(fromFlag, unset))
+}
@@ -0,0 +1,78 @@
+package cephdoctor
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/appconfig"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/fscluster"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/sqlcluster"
+)
+
+const (
+	repositoryFile   = "file"
+	repositorySQLite = "sqlite"
+)
+
+var errRepositoryCommandUnsupported = errors.New("repository command is not supported by this repository")
+
+// repositoryMaintainer runs the repo subcommands against the selected repository.
+type repositoryMaintainer interface {
+	keyEncrypter
+	fileMigrator
+	repositoryDoctor
+}
+
+// repositoryBackend is the cluster repository selected by repositoryKind.
+type repositoryBackend struct {
+	repo       tolerantClusterRepository
+	maintainer repositoryMaintainer
+	close      func() error
+}
+
+// openRepository opens the cluster repository named by kind. Keys are sealed with the passphrase
+// in passphraseEnv when it is set.
+func openRepository(kind string) (*repositoryBackend, error) {
+	passphrase := []byte(os.Getenv(passphraseEnv))
+
+	if kind == repositorySQLite {
+		repo, err := sqlcluster.NewRepository("", sqlcluster.WithPassphrase(passphrase))
+		if err != nil {
+			return nil, fmt.Errorf("new sqlite repository: %w", err)
+		}
+
+		maintainer := unsupportedMaintainer{TolerantClusterLister: repo, kind: kind}
+
+		return &repositoryBackend{repo: repo, maintainer: maintainer, close: repo.Close}, nil
+	}
+
+	repo, err := fscluster.NewRepository("", fscluster.WithPassphrase(passphrase))
+	if err != nil {
+		return nil, fmt.Errorf("new file repository: %w", err)
+	}
+
+	return &repositoryBackend{repo: repo, maintainer: repo, close: func() error { return nil }}, nil
+}
+
+// repositoryKind picks the repository from --repository or CEPHDOCTOR_REPOSITORY, which kong has
+// already merged with the flag winning, then from the config file, then the file repository.
+func repositoryKind(selected string, config *appconfig.Config) string {
+	if selected != "" {
+		return selected
+	}
+
+	if config.Repository != "" {
+		return config.Repository
+	}
+
+	return repositoryFile
+}
+
+func closeRepository(backend *repositoryBackend) {
+	err := backend.close()
+	if err != nil {
+		slog.Warn("close repository", "error", err)
+	}
+}
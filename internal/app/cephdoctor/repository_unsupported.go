@@ -0,0 +1,32 @@
+package cephdoctor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// unsupportedMaintainer answers the file-specific repo subcommands for repositories without
+// cluster files. Listing still works so repo doctor can report unreadable records.
+type unsupportedMaintainer struct {
+	domain.TolerantClusterLister
+
+	kind string
+}
+
+func (m unsupportedMaintainer) EncryptKeys(context.Context) (int, error) {
+	return 0, fmt.Errorf("%w: %s", errRepositoryCommandUnsupported, m.kind)
+}
+
+func (m unsupportedMaintainer) MigrateFiles(context.Context) (int, []domain.LoadProblem, error) {
+	return 0, nil, fmt.Errorf("%w: %s", errRepositoryCommandUnsupported, m.kind)
+}
+
+func (m unsupportedMaintainer) RepairFiles(context.Context) ([]string, error) {
+	return nil, fmt.Errorf("%w: %s", errRepositoryCommandUnsupported, m.kind)
+}
+
+func (m unsupportedMaintainer) QuarantineFiles(context.Context) ([]string, error) {
+	return nil, fmt.Errorf("%w: %s", errRepositoryCommandUnsupported, m.kind)
+}
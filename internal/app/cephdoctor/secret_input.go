@@ -0,0 +1,29 @@
+package cephdoctor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readSecret reads a key or passphrase from path, or from stdin when path is "-",
+// without surrounding whitespace.
+func readSecret(path string, stdin io.Reader) (string, error) {
+	var (
+		content []byte
+		err     error
+	)
+
+	if path == "-" {
+		content, err = io.ReadAll(stdin)
+	} else {
+		content, err = os.ReadFile(path) //nolint:gosec // The secret file is chosen by the operator.
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("read secret: %w", err)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,60 @@
+package cephdoctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+var errNoReadableClusters = errors.New("no stored cluster could be read; run repo doctor")
+
+// tolerantRepository lists the clusters that load and reports the rest on warnings, so one broken
+// file does not make every command fail. When nothing loads the listing fails instead of looking
+// empty. repo doctor reports and fixes the skipped files.
+type tolerantRepository struct {
+	domain.ClusterRepository
+
+	lister   domain.TolerantClusterLister
+	warnings io.Writer
+}
+
+type tolerantClusterRepository interface {
+	domain.ClusterRepository
+	domain.TolerantClusterLister
+}
+
+func newTolerantRepository(repo tolerantClusterRepository, warnings io.Writer) *tolerantRepository {
+	return &tolerantRepository{ClusterRepository: repo, lister: repo, warnings: warnings}
+}
+
+func (r *tolerantRepository) ListClusters(ctx context.Context) ([]*domain.Cluster, error) {
+	clusters, problems, err := r.lister.ListClustersTolerant(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // The wrapper is transparent to callers.
+	}
+
+	if len(problems) == 0 {
+		return clusters, nil
+	}
+
+	for _, problem := range problems {
+		slog.Warn("skipping unreadable cluster file; run repo doctor",
+			"source", problem.Source, "error", problem.Err)
+	}
+
+	if len(clusters) == 0 {
+		return nil, fmt.Errorf("%w: %d skipped, first %s: %w",
+			errNoReadableClusters, len(problems), problems[0].Source, problems[0].Err)
+	}
+
+	_, err = fmt.Fprintf(r.warnings, "Skipped %d unreadable cluster records; run repo doctor.\n", len(problems))
+	if err != nil {
+		return nil, fmt.Errorf("write warning: %w", err)
+	}
+
+	return clusters, nil
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Backend names the mechanism used to run ceph commands for a cluster.
+// The zero value leaves the choice to the caller's default.
+type Backend string
+
+const (
+	BackendDefault Backend = ""
+	BackendPodman  Backend = "podman"
+	BackendLocal   Backend = "local"
+	BackendSSH     Backend = "ssh"
+)
+
+var ErrUnknownBackend = errors.New("unknown backend")
+
+func ParseBackend(value string) (Backend, error) {
+	backend := Backend(value)
+
+	switch backend {
+	case BackendDefault, BackendPodman, BackendLocal, BackendSSH:
+		return backend, nil
+	default:
+		return BackendDefault, fmt.Errorf("%w: %s", ErrUnknownBackend, value)
+	}
+}
+
+// WithBackend pins the cluster to a backend instead of the caller's default.
+func WithBackend(backend Backend) ClusterOption {
+	return func(c *Cluster) error {
+		parsed, err := ParseBackend(string(backend))
+		if err != nil {
+			return err
+		}
+
+		c.backend = parsed
+
+		return nil
+	}
+}
+
+func (c *Cluster) Backend() Backend {
+	return c.backend
+}
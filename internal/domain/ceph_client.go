@@ -1,12 +1,17 @@
 package domain
 
-import "context"
-
-type CephStatus struct {
-	Stdout string
-	Stderr string
-}
+import (
+	"context"
+	"io"
+)
 
 type CephClient interface {
 	Status(ctx context.Context, cluster *Cluster) (*CephStatus, error)
 }
+
+// CephExecutor runs a command from CephTools against a cluster, streaming its output to stdout
+// and stderr as it is produced. It returns the command's exit code; err is only set when the
+// command could not be run at all.
+type CephExecutor interface {
+	Exec(ctx context.Context, cluster *Cluster, command CephCommand, stdout, stderr io.Writer) (int, error)
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrEmptyCephCommand    = errors.New("ceph command is empty")
+	ErrUnknownCephTool     = errors.New("unknown ceph tool")
+	ErrMutatingCephCommand = errors.New("command is not in the read-only allowlist")
+)
+
+// CephTools lists the CLI tools that can be run against a registered cluster. They all accept
+// the --conf, --keyring and --name options the generated cluster configuration is passed with.
+//
+//nolint:gochecknoglobals // The registry is a fixed list of tools.
+var CephTools = []string{"ceph", "rados", "rbd", "radosgw-admin"}
+
+// CephCommand is a command line for one of CephTools, such as ceph osd tree.
+type CephCommand struct {
+	Tool string
+	Args []string
+}
+
+// NewCephCommand splits argv into the tool and its arguments.
+func NewCephCommand(argv []string) (CephCommand, error) {
+	if len(argv) == 0 {
+		return CephCommand{}, ErrEmptyCephCommand
+	}
+
+	if !slices.Contains(CephTools, argv[0]) {
+		return CephCommand{}, fmt.Errorf("%w: %q, expected one of %s", ErrUnknownCephTool, argv[0],
+			strings.Join(CephTools, ", "))
+	}
+
+	return CephCommand{Tool: argv[0], Args: slices.Clone(argv[1:])}, nil
+}
+
+// CheckReadOnly reports ErrMutatingCephCommand unless the command is in readOnlyCommands.
+// Options are stripped together with the values of valueOptions, and every remaining positional
+// word must match an entry, so options cannot hide a mutating subcommand. Options writing a local
+// file are refused.
+func (c CephCommand) CheckReadOnly() error {
+	words, options := c.splitOptions()
+	if index := slices.IndexFunc(options, writesLocalFile); index >= 0 {
+		return fmt.Errorf("%w: %s writes a local file: %s", ErrMutatingCephCommand, options[index], c)
+	}
+
+	if c.Tool == "ceph" && len(words) == 0 && (slices.Contains(options, "-s") || slices.Contains(options, "--status")) {
+		return nil
+	}
+
+	for _, entry := range readOnlyCommands[c.Tool] {
+		if matchesReadOnlyEntry(strings.Fields(entry), words) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrMutatingCephCommand, c)
+}
+
+func (c CephCommand) String() string {
+	return strings.Join(append([]string{c.Tool}, c.Args...), " ")
+}
+
+// matchesReadOnlyEntry reports whether words spell out entry. An entry ending in "*" also
+// accepts any number of further positional arguments, such as a pool or image name.
+func matchesReadOnlyEntry(entry, words []string) bool {
+	if last := len(entry) - 1; entry[last] == "*" {
+		entry = entry[:last]
+
+		return len(words) >= len(entry) && slices.Equal(entry, words[:len(entry)])
+	}
+
+	return slices.Equal(entry, words)
+}
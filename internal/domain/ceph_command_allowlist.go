@@ -0,0 +1,37 @@
+package domain
+
+// readOnlyCommands lists, per tool, the subcommands that only read cluster state. Commands that
+// can print secrets, such as auth ls, config dump, orch ls specs or radosgw-admin user info and
+// zone get, and commands writing local files such as rados get are deliberately left out.
+//
+//nolint:gochecknoglobals // The registry is a fixed table of commands.
+var readOnlyCommands = map[string][]string{
+	"ceph": {
+		"status", "health", "health detail", "df", "df detail", "versions", "version", "quorum_status",
+		"mon stat", "mon dump", "mgr stat", "mgr dump", "mgr services", "mgr module ls", "mgr metadata *",
+		"osd tree", "osd df", "osd df tree", "osd ls", "osd dump", "osd stat", "osd perf", "osd find *",
+		"osd metadata *", "osd utilization", "osd blocked-by", "osd pool ls", "osd pool ls detail",
+		"osd pool get *", "osd pool stats *", "osd pool autoscale-status", "osd crush tree",
+		"osd crush dump", "osd crush rule ls", "osd crush rule dump *",
+		"pg stat", "pg dump", "pg dump *", "pg ls *", "pg ls-by-pool *", "pg ls-by-osd *",
+		"pg ls-by-primary *", "pg query *", "pg map *", "pg dump_stuck *",
+		"fs ls", "fs status *", "fs dump", "fs get *", "mds stat",
+		"config ls", "log last *", "features",
+		"balancer status", "crash ls", "crash info *", "time-sync-status",
+		"orch status", "orch ps *", "orch host ls", "orch device ls *",
+	},
+	"rados": {
+		"lspools", "df", "ls", "lssnap", "stat *", "listxattr *", "getxattr *", "listomapkeys *",
+		"listomapvals *", "listwatchers *", "list-inconsistent-pg *",
+		"list-inconsistent-obj *", "list-inconsistent-snapset *",
+	},
+	"rbd": {
+		"ls *", "list *", "info *", "du *", "status *", "snap ls *", "snap list *", "children *",
+		"pool stats *", "namespace ls *", "trash ls *", "mirror pool status *", "mirror image status *",
+	},
+	"radosgw-admin": {
+		"user list", "bucket list", "bucket stats", "bucket limit check",
+		"zone list", "zonegroup get", "zonegroup list", "realm list", "period get", "sync status",
+		"metadata list *", "usage show", "gc list", "lc list",
+	},
+}
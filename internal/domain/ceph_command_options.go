@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// valueOptions lists, per tool, the options whose value follows as a separate word. Options
+// missing here are assumed to take no value, which only makes CheckReadOnly stricter.
+//
+//nolint:gochecknoglobals // The registry is a fixed table of options.
+var valueOptions = map[string][]string{
+	"ceph":          {"-f", "--format", "--connect-timeout", "--watch-channel"},
+	"rados":         {"-p", "--pool", "-N", "--namespace", "--format"},
+	"rbd":           {"-p", "--pool", "--namespace", "--image", "--snap", "--format"},
+	"radosgw-admin": {"--uid", "--bucket", "--format", "--rgw-zone", "--rgw-zonegroup", "--rgw-realm"},
+}
+
+// splitOptions separates the positional words from the options. An option in valueOptions also
+// takes the following word as its value unless it was given as --option=value.
+func (c CephCommand) splitOptions() ([]string, []string) {
+	var words, options []string
+
+	for index := 0; index < len(c.Args); index++ {
+		word := c.Args[index]
+		if word == "-" || !strings.HasPrefix(word, "-") {
+			words = append(words, word)
+
+			continue
+		}
+
+		options = append(options, word)
+		if slices.Contains(valueOptions[c.Tool], word) {
+			index++
+		}
+	}
+
+	return words, options
+}
+
+func writesLocalFile(option string) bool {
+	name, _, _ := strings.Cut(option, "=")
+
+	return name == "--out-file" || name == "--output-file" ||
+		strings.HasPrefix(option, "-o") && !strings.HasPrefix(option, "--")
+}
@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCephCommand(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	command, err := domain.NewCephCommand([]string{"rbd", "ls", "-p", "rbd"})
+	_, emptyErr := domain.NewCephCommand(nil)
+	_, unknownErr := domain.NewCephCommand([]string{"sh", "-c", "true"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, domain.CephCommand{Tool: "rbd", Args: []string{"ls", "-p", "rbd"}}, command)
+	require.Equal(t, "rbd ls -p rbd", command.String())
+	require.ErrorIs(t, emptyErr, domain.ErrEmptyCephCommand)
+	require.ErrorIs(t, unknownErr, domain.ErrUnknownCephTool)
+}
+
+func TestCephCommand_CheckReadOnly(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"ceph -s":                                  true,
+		"ceph osd tree":                            true,
+		"ceph osd tree --format json":              true,
+		"ceph osd pool get rbd size":               true,
+		"ceph health detail":                       true,
+		"rados ls -p rbd":                          true,
+		"rbd snap ls rbd/image":                    true,
+		"radosgw-admin user list":                  true,
+		"radosgw-admin bucket stats --bucket logs": true,
+		"ceph --format json osd tree":              true,
+		"ceph -s -f json":                          true,
+		"rados -p rbd ls":                          true,
+		"ceph":                                     false,
+		"ceph health mute OSD_DOWN":                false,
+		"ceph osd out 3":                           false,
+		"ceph osd pool set rbd size 2":             false,
+		"ceph auth ls":                             false,
+		"ceph health -f json mute OSD_DOWN":        false,
+		"ceph osd tree --format json extra":        false,
+		"ceph status -o /tmp/status":               false,
+		"ceph status --out-file=/tmp/status":       false,
+		"rados -p rbd getomapval obj key":          false,
+		"radosgw-admin user info --uid=ops":        false,
+		"radosgw-admin zone get":                   false,
+		"ceph config dump":                         false,
+		"ceph config get mgr mgr/dashboard/x":      false,
+		"ceph orch ls --export":                    false,
+		"ceph osd tree extra":                      false,
+		"rados -p rbd rm object":                   false,
+		"rbd rm rbd/image":                         false,
+		"radosgw-admin user create --uid=ops":      false,
+	}
+
+	for line, readOnly := range tests {
+		t.Run(line, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			command, err := domain.NewCephCommand(strings.Fields(line))
+			require.NoError(t, err)
+
+			// Act
+			err = command.CheckReadOnly()
+
+			// Assert
+			if readOnly {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, domain.ErrMutatingCephCommand)
+		})
+	}
+}
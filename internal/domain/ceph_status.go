@@ -0,0 +1,91 @@
+package domain
+
+// HealthStatus is the overall or per-check health level reported by Ceph.
+type HealthStatus string
+
+const (
+	HealthOK   HealthStatus = "HEALTH_OK"
+	HealthWarn HealthStatus = "HEALTH_WARN"
+	HealthErr  HealthStatus = "HEALTH_ERR"
+)
+
+// CephStatus is the typed form of `ceph status --format json`.
+// Stdout and Stderr keep the raw command output for debugging. Version is the release the
+// cluster reported, or "" when the client did not detect it.
+type CephStatus struct {
+	FSID    string
+	Version string
+	Health  Health
+	MonMap  MonMap
+	MgrMap  MgrMap
+	OSDMap  OSDMap
+	PGMap   PGMap
+	Usage   Usage
+	IO      IORates
+	Stdout  string
+	Stderr  string
+}
+
+type Health struct {
+	Status HealthStatus
+	Checks []HealthCheck
+}
+
+// HealthCheck is a single entry of the health checks map, identified by its code (e.g. OSD_DOWN).
+type HealthCheck struct {
+	Code     string
+	Severity HealthStatus
+	Message  string
+	Count    int
+	Muted    bool
+}
+
+type MonMap struct {
+	Epoch       int
+	NumMons     int
+	QuorumNames []string
+}
+
+type MgrMap struct {
+	Available   bool
+	NumStandbys int
+	Modules     []string
+}
+
+type OSDMap struct {
+	Epoch          int
+	NumOSDs        int
+	NumUpOSDs      int
+	NumInOSDs      int
+	NumRemappedPGs int
+}
+
+type PGMap struct {
+	NumPGs           int
+	NumPools         int
+	NumObjects       int64
+	States           []PGStateCount
+	DegradedObjects  int64
+	DegradedRatio    float64
+	MisplacedObjects int64
+	MisplacedRatio   float64
+}
+
+type PGStateCount struct {
+	State string
+	Count int
+}
+
+type Usage struct {
+	DataBytes  uint64
+	UsedBytes  uint64
+	AvailBytes uint64
+	TotalBytes uint64
+}
+
+type IORates struct {
+	ReadBytesPerSec  int64
+	WriteBytesPerSec int64
+	ReadOpsPerSec    int64
+	WriteOpsPerSec   int64
+}
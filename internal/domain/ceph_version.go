@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidCephVersion = errors.New("invalid ceph version")
+
+// ParseCephVersion validates a dotted release number such as 18.2.7.
+// Build suffixes and tag prefixes such as "18.2.7-0" or "v18.2.7" are rejected.
+func ParseCephVersion(value string) (string, error) {
+	for part := range strings.SplitSeq(value, ".") {
+		if part == "" || strings.ContainsFunc(part, isNotDigit) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidCephVersion, value)
+		}
+	}
+
+	return value, nil
+}
+
+// LookupByCephVersion finds the entry whose key is the longest dotted prefix of version,
+// so "18.2" wins over "18" for 18.2.7. It reports false when version is empty or nothing matches.
+func LookupByCephVersion(entries map[string]string, version string) (string, bool) {
+	for prefix := version; prefix != ""; {
+		if value, ok := entries[prefix]; ok {
+			return value, true
+		}
+
+		cut := strings.LastIndexByte(prefix, '.')
+		if cut < 0 {
+			break
+		}
+
+		prefix = prefix[:cut]
+	}
+
+	return "", false
+}
+
+// WithCephVersion records the release the cluster was last seen running. An empty version clears it.
+func WithCephVersion(version string) ClusterOption {
+	return func(c *Cluster) error {
+		if version == "" {
+			c.cephVersion = ""
+
+			return nil
+		}
+
+		parsed, err := ParseCephVersion(version)
+		if err != nil {
+			return err
+		}
+
+		c.cephVersion = parsed
+
+		return nil
+	}
+}
+
+// CephVersion returns the cached cluster release, or "" when it has not been detected yet.
+func (c *Cluster) CephVersion() string {
+	return c.cephVersion
+}
+
+func isNotDigit(r rune) bool {
+	return r < '0' || r > '9'
+}
@@ -0,0 +1,67 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCephVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		wantErr error
+	}{
+		{input: "18.2.7", wantErr: nil},
+		{input: "19", wantErr: nil},
+		{input: "", wantErr: nil},
+		{input: "v18.2.7", wantErr: domain.ErrInvalidCephVersion},
+		{input: "18.2.7-0", wantErr: domain.ErrInvalidCephVersion},
+		{input: "18..7", wantErr: domain.ErrInvalidCephVersion},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			cluster, err := domain.NewCluster("alpha", "secret", []string{"10.0.0.1"}, domain.WithCephVersion(test.input))
+
+			// Assert
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.input, cluster.CephVersion())
+		})
+	}
+}
+
+func TestLookupByCephVersion(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	entries := map[string]string{"18": "reef", "18.2": "reef-2", "17.2.8": "quincy-8"}
+
+	// Act
+	minor, minorOK := domain.LookupByCephVersion(entries, "18.2.7")
+	major, majorOK := domain.LookupByCephVersion(entries, "18.1.0")
+	exact, exactOK := domain.LookupByCephVersion(entries, "17.2.8")
+	_, missingOK := domain.LookupByCephVersion(entries, "17.2.7")
+	_, emptyOK := domain.LookupByCephVersion(entries, "")
+
+	// Assert
+	require.True(t, minorOK)
+	require.Equal(t, "reef-2", minor)
+	require.True(t, majorOK)
+	require.Equal(t, "reef", major)
+	require.True(t, exactOK)
+	require.Equal(t, "quincy-8", exact)
+	require.False(t, missingOK)
+	require.False(t, emptyOK)
+}
@@ -4,17 +4,26 @@ import "errors"
 
 // Cluster represents a registered Ceph cluster.
 type Cluster struct {
-	name  string
-	key   string
-	hosts *Hosts
+	name    string
+	key     string
+	hosts   *Hosts
+	entity  string
+	backend Backend
+	ssh     *SSHTarget
+	labels  map[string]string
+
+	cephVersion string
 }
 
+// ClusterOption sets an optional cluster attribute in NewCluster.
+type ClusterOption func(*Cluster) error
+
 var (
 	ErrEmptyClusterName = errors.New("cluster name is empty")
 	ErrEmptyClusterKey  = errors.New("cluster key is empty")
 )
 
-func NewCluster(name, key string, hosts []string) (*Cluster, error) {
+func NewCluster(name, key string, hosts []string, opts ...ClusterOption) (*Cluster, error) {
 	if name == "" {
 		return nil, ErrEmptyClusterName
 	}
@@ -23,16 +32,59 @@ func NewCluster(name, key string, hosts []string) (*Cluster, error) {
 		return nil, ErrEmptyClusterKey
 	}
 
+	err := validateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
 	clusterHosts, err := NewHosts(hosts)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Cluster{
-		name:  name,
-		key:   key,
-		hosts: clusterHosts,
-	}, nil
+	cluster := &Cluster{
+		name:    name,
+		key:     key,
+		hosts:   clusterHosts,
+		entity:  DefaultEntity,
+		backend: BackendDefault,
+		ssh:     nil,
+		labels:  nil,
+
+		cephVersion: "",
+	}
+
+	for _, opt := range opts {
+		err = opt(cluster)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cluster, nil
+}
+
+// Edited returns a copy of the cluster with a new name, key and hosts, validated like NewCluster.
+// Optional attributes are carried over and opts are applied on top of them.
+func (c *Cluster) Edited(name, key string, hosts []string, opts ...ClusterOption) (*Cluster, error) {
+	validated, err := NewCluster(name, key, hosts)
+	if err != nil {
+		return nil, err
+	}
+
+	edited := *c
+	edited.name = validated.name
+	edited.key = validated.key
+	edited.hosts = validated.hosts
+
+	for _, opt := range opts {
+		err = opt(&edited)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &edited, nil
 }
 
 func (c *Cluster) Name() string {
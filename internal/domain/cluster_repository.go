@@ -13,6 +13,23 @@ var (
 type ClusterRepository interface {
 	CreateCluster(ctx context.Context, cluster *Cluster) error
 	UpdateCluster(ctx context.Context, cluster *Cluster) error
+	// RenameCluster replaces the cluster stored as oldName with cluster, stored under cluster.Name().
+	RenameCluster(ctx context.Context, oldName string, cluster *Cluster) error
 	ListClusters(ctx context.Context) ([]*Cluster, error)
 	DeleteCluster(ctx context.Context, name string) error
+	// RecordCephVersion changes only the cached release of the stored cluster, so it cannot undo
+	// an edit made since the cluster was read. An empty version clears it.
+	RecordCephVersion(ctx context.Context, name, version string) error
+}
+
+// LoadProblem describes a stored cluster record that could not be loaded.
+type LoadProblem struct {
+	Source string
+	Err    error
+}
+
+// TolerantClusterLister is implemented by repositories that can skip unreadable records
+// and report them instead of failing the whole listing.
+type TolerantClusterLister interface {
+	ListClustersTolerant(ctx context.Context) ([]*Cluster, []LoadProblem, error)
 }
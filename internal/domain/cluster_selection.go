@@ -0,0 +1,36 @@
+package domain
+
+import "fmt"
+
+// SelectClusters keeps the clusters named in names, or all of them when names is empty,
+// that also match selector. The given order is kept. A name without a cluster is an error.
+func SelectClusters(clusters []*Cluster, names []string, selector Selector) ([]*Cluster, error) {
+	byName := make(map[string]bool, len(clusters))
+	for _, cluster := range clusters {
+		byName[cluster.Name()] = true
+	}
+
+	wanted := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		if !byName[name] {
+			return nil, fmt.Errorf("%w: %q", ErrClusterNotFound, name)
+		}
+
+		wanted[name] = true
+	}
+
+	selected := make([]*Cluster, 0, len(clusters))
+
+	for _, cluster := range clusters {
+		if len(names) > 0 && !wanted[cluster.Name()] {
+			continue
+		}
+
+		if selector.Matches(cluster.labels) {
+			selected = append(selected, cluster)
+		}
+	}
+
+	return selected, nil
+}
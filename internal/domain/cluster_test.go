@@ -83,3 +83,85 @@ func TestNewCluster_ValidatesNameAndKeyErrors(t *testing.T) {
 		require.Nil(t, cluster)
 	})
 }
+
+func TestNewCluster_WithBackend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("known backend", func(t *testing.T) {
+		t.Parallel()
+
+		// Act
+		cluster, err := domain.NewCluster(testClusterName, testClusterKey, []string{"10.0.0.1"},
+			domain.WithBackend(domain.BackendLocal))
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, domain.BackendLocal, cluster.Backend())
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		t.Parallel()
+
+		// Act
+		cluster, err := domain.NewCluster(testClusterName, testClusterKey, []string{"10.0.0.1"},
+			domain.WithBackend("docker"))
+
+		// Assert
+		require.ErrorIs(t, err, domain.ErrUnknownBackend)
+		require.Nil(t, cluster)
+	})
+}
+
+func TestCluster_EditedKeepsOptionalAttributes(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	original, err := domain.NewCluster(testClusterName, testClusterKey, []string{"10.0.0.1"},
+		domain.WithBackend(domain.BackendLocal))
+	require.NoError(t, err)
+
+	// Act
+	edited, err := original.Edited("cluster-b", "rotated", []string{"10.0.0.2"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "cluster-b", edited.Name())
+	require.Equal(t, "rotated", edited.Key())
+	require.Equal(t, []string{"10.0.0.2:3300"}, edited.Hosts())
+	require.Equal(t, domain.BackendLocal, edited.Backend())
+	require.Equal(t, testClusterName, original.Name())
+}
+
+func TestCluster_EditedValidates(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	original, err := domain.NewCluster(testClusterName, testClusterKey, []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	// Act
+	edited, err := original.Edited(testClusterName, "", []string{"10.0.0.1"})
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrEmptyClusterKey)
+	require.Nil(t, edited)
+}
+
+func TestNewCluster_WithEntity(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	hosts := []string{"10.0.0.1"}
+
+	// Act
+	defaulted, defaultErr := domain.NewCluster("alpha", "secret", hosts)
+	scoped, scopedErr := domain.NewCluster("alpha", "secret", hosts, domain.WithEntity("client.cephdoctor"))
+	_, invalidErr := domain.NewCluster("alpha", "secret", hosts, domain.WithEntity("osd.0"))
+
+	// Assert
+	require.NoError(t, defaultErr)
+	require.Equal(t, domain.DefaultEntity, defaulted.Entity())
+	require.NoError(t, scopedErr)
+	require.Equal(t, "client.cephdoctor", scoped.Entity())
+	require.ErrorIs(t, invalidErr, domain.ErrInvalidEntity)
+}
@@ -0,0 +1,46 @@
+package diagnosis
+
+import (
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+const (
+	capacityRuleID        = "capacity"
+	capacityWarningRatio  = 0.75
+	capacityCriticalRatio = 0.85
+	percent               = 100
+)
+
+// capacityRule reports raw usage approaching or past the default nearfull ratio.
+type capacityRule struct{}
+
+func (capacityRule) ID() string {
+	return capacityRuleID
+}
+
+func (r capacityRule) Check(status *domain.CephStatus) []Finding {
+	usage := status.Usage
+	if usage.TotalBytes == 0 {
+		return nil
+	}
+
+	ratio := float64(usage.UsedBytes) / float64(usage.TotalBytes)
+	if ratio < capacityWarningRatio {
+		return nil
+	}
+
+	severity := SeverityWarning
+	if ratio >= capacityCriticalRatio {
+		severity = SeverityCritical
+	}
+
+	return []Finding{{
+		RuleID:      r.ID(),
+		Severity:    severity,
+		Summary:     fmt.Sprintf("raw capacity is %.1f%% used", ratio*percent),
+		Evidence:    []string{fmt.Sprintf("pgmap.bytes_used=%d bytes_total=%d", usage.UsedBytes, usage.TotalBytes)},
+		Remediation: "Add OSDs or free space before OSDs hit the nearfull (85%) ratio.",
+	}}
+}
@@ -0,0 +1,60 @@
+package diagnosis
+
+import (
+	"sort"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// Rule inspects a cluster status and returns zero or more findings.
+type Rule interface {
+	ID() string
+	Check(status *domain.CephStatus) []Finding
+}
+
+// Engine runs every registered rule against a cluster status.
+type Engine struct {
+	rules []Rule
+}
+
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{rules: append([]Rule(nil), rules...)}
+}
+
+func (e *Engine) Register(rule Rule) {
+	e.rules = append(e.rules, rule)
+}
+
+func (e *Engine) Rules() []Rule {
+	return append([]Rule(nil), e.rules...)
+}
+
+// Diagnose returns findings ordered by descending severity, then by rule ID.
+func (e *Engine) Diagnose(status *domain.CephStatus) []Finding {
+	findings := make([]Finding, 0)
+	for _, rule := range e.rules {
+		findings = append(findings, rule.Check(status)...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity.rank() != findings[j].Severity.rank() {
+			return findings[i].Severity.rank() > findings[j].Severity.rank()
+		}
+
+		return findings[i].RuleID < findings[j].RuleID
+	})
+
+	return findings
+}
+
+// DefaultRules returns the built-in rule set.
+func DefaultRules() []Rule {
+	return []Rule{
+		healthChecksRule{},
+		monitorQuorumRule{},
+		managerRule{},
+		osdRule{},
+		placementGroupRule{},
+		capacityRule{},
+	}
+}
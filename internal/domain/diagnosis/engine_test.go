@@ -0,0 +1,65 @@
+package diagnosis_test
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/diagnosis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_OrdersFindingsBySeverityThenRuleID(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	engine := diagnosis.NewEngine(
+		stubRule{id: "b", severity: diagnosis.SeverityInfo},
+		stubRule{id: "c", severity: diagnosis.SeverityCritical},
+		stubRule{id: "a", severity: diagnosis.SeverityWarning},
+	)
+	engine.Register(stubRule{id: "a", severity: diagnosis.SeverityCritical})
+
+	// Act
+	findings := engine.Diagnose(new(domain.CephStatus))
+
+	// Assert
+	require.Equal(t, []string{
+		"a/critical/stub",
+		"c/critical/stub",
+		"a/warning/stub",
+		"b/info/stub",
+	}, describe(findings))
+}
+
+func TestEngine_RulesReturnsCopy(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	engine := diagnosis.NewEngine(diagnosis.DefaultRules()...)
+
+	// Act
+	rules := engine.Rules()
+	rules[0] = stubRule{id: "changed", severity: diagnosis.SeverityInfo}
+
+	// Assert
+	require.NotEqual(t, "changed", engine.Rules()[0].ID())
+}
+
+type stubRule struct {
+	id       string
+	severity diagnosis.Severity
+}
+
+func (s stubRule) ID() string {
+	return s.id
+}
+
+func (s stubRule) Check(*domain.CephStatus) []diagnosis.Finding {
+	return []diagnosis.Finding{{
+		RuleID:      s.id,
+		Severity:    s.severity,
+		Summary:     "stub",
+		Evidence:    nil,
+		Remediation: "",
+	}}
+}
@@ -0,0 +1,44 @@
+// Package diagnosis inspects collected cluster data and reports findings.
+package diagnosis
+
+// Severity ranks how urgently a finding needs attention.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Finding is a single problem reported by a rule.
+type Finding struct {
+	RuleID      string
+	Severity    Severity
+	Summary     string
+	Evidence    []string
+	Remediation string
+}
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 1
+	case SeverityInfo:
+		return 0
+	default:
+		return 0
+	}
+}
+
+// HasCritical reports whether any finding is critical.
+func HasCritical(findings []Finding) bool {
+	for _, finding := range findings {
+		if finding.Severity == SeverityCritical {
+			return true
+		}
+	}
+
+	return false
+}
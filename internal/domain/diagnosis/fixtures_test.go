@@ -0,0 +1,100 @@
+package diagnosis_test
+
+import "github.com/neatflowcv/ceph-doctor/internal/domain"
+
+// The fixtures mirror what the status parser produces for a healthy, a degraded and an
+// outage cluster, so the rules are tested without the infrastructure layer.
+
+func healthyStatus() *domain.CephStatus {
+	return newStatus(
+		domain.Health{Status: domain.HealthOK, Checks: []domain.HealthCheck{}},
+		domain.MonMap{Epoch: 3, NumMons: 3, QuorumNames: []string{"mon-a", "mon-b", "mon-c"}},
+		domain.MgrMap{Available: true, NumStandbys: 1, Modules: []string{"dashboard", "prometheus"}},
+		domain.OSDMap{Epoch: 80, NumOSDs: 6, NumUpOSDs: 6, NumInOSDs: 6, NumRemappedPGs: 0},
+		newPGMap(129, 4, 1200, 0, 0, domain.PGStateCount{State: "active+clean", Count: 129}),
+		newUsage(17179869184, 103079215104, 120259084288),
+	)
+}
+
+func degradedStatus() *domain.CephStatus {
+	return newStatus(
+		domain.Health{Status: domain.HealthWarn, Checks: []domain.HealthCheck{
+			newCheck("OSD_DOWN", domain.HealthWarn, "1 osds down", 1, false),
+			newCheck("PG_DEGRADED", domain.HealthWarn,
+				"Degraded data redundancy: 12/345 objects degraded (3.478%), 4 pgs degraded", 12, false),
+			newCheck("RECENT_CRASH", domain.HealthWarn, "1 daemons have recently crashed", 1, true),
+		}},
+		domain.MonMap{Epoch: 3, NumMons: 3, QuorumNames: []string{"mon-a", "mon-b", "mon-c"}},
+		domain.MgrMap{Available: true, NumStandbys: 1, Modules: []string{"dashboard"}},
+		domain.OSDMap{Epoch: 152, NumOSDs: 6, NumUpOSDs: 5, NumInOSDs: 6, NumRemappedPGs: 0},
+		newPGMap(97, 3, 345, 12, 0.011594,
+			domain.PGStateCount{State: "active+clean", Count: 93},
+			domain.PGStateCount{State: "active+undersized+degraded", Count: 4}),
+		newUsage(3758096384, 60129542144, 63887638528),
+	)
+}
+
+func outageStatus() *domain.CephStatus {
+	return newStatus(
+		domain.Health{Status: domain.HealthErr, Checks: []domain.HealthCheck{
+			newCheck("MON_DOWN", domain.HealthWarn, "2/3 mons down, quorum mon-a", 2, false),
+			newCheck("PG_AVAILABILITY", domain.HealthErr, "Reduced data availability: 8 pgs inactive", 8, false),
+		}},
+		domain.MonMap{Epoch: 5, NumMons: 3, QuorumNames: []string{"mon-a"}},
+		domain.MgrMap{Available: false, NumStandbys: 0, Modules: []string{}},
+		domain.OSDMap{Epoch: 301, NumOSDs: 4, NumUpOSDs: 4, NumInOSDs: 3, NumRemappedPGs: 2},
+		newPGMap(64, 2, 9000, 0, 0,
+			domain.PGStateCount{State: "active+clean", Count: 56},
+			domain.PGStateCount{State: "peering", Count: 8}),
+		newUsage(92341796864, 10737418240, 103079215104),
+	)
+}
+
+func newStatus(
+	health domain.Health,
+	monMap domain.MonMap,
+	mgrMap domain.MgrMap,
+	osdMap domain.OSDMap,
+	pgMap domain.PGMap,
+	usage domain.Usage,
+) *domain.CephStatus {
+	return &domain.CephStatus{
+		FSID:    "5b1d7c2a-0f3e-11ef-8a6e-525400c0ffee",
+		Version: "",
+		Health:  health,
+		MonMap:  monMap,
+		MgrMap:  mgrMap,
+		OSDMap:  osdMap,
+		PGMap:   pgMap,
+		Usage:   usage,
+		IO:      domain.IORates{ReadBytesPerSec: 0, WriteBytesPerSec: 0, ReadOpsPerSec: 0, WriteOpsPerSec: 0},
+		Stdout:  "",
+		Stderr:  "",
+	}
+}
+
+func newCheck(code string, severity domain.HealthStatus, message string, count int, muted bool) domain.HealthCheck {
+	return domain.HealthCheck{Code: code, Severity: severity, Message: message, Count: count, Muted: muted}
+}
+
+func newPGMap(
+	pgs, pools int,
+	objects, degraded int64,
+	degradedRatio float64,
+	states ...domain.PGStateCount,
+) domain.PGMap {
+	return domain.PGMap{
+		NumPGs:           pgs,
+		NumPools:         pools,
+		NumObjects:       objects,
+		States:           states,
+		DegradedObjects:  degraded,
+		DegradedRatio:    degradedRatio,
+		MisplacedObjects: 0,
+		MisplacedRatio:   0,
+	}
+}
+
+func newUsage(used, avail, total uint64) domain.Usage {
+	return domain.Usage{DataBytes: 0, UsedBytes: used, AvailBytes: avail, TotalBytes: total}
+}
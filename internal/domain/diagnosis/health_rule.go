@@ -0,0 +1,71 @@
+package diagnosis
+
+import (
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+const (
+	healthChecksRuleID         = "health-checks"
+	defaultHealthRemediation   = "Run `ceph health detail` to inspect the affected daemons and objects."
+	healthCheckEvidenceMessage = "%s: %s"
+)
+
+//nolint:gochecknoglobals // Read-only lookup table of well-known health check codes.
+var healthCheckRemediations = map[string]string{
+	"MON_DOWN":           "Check the monitor daemon and its host; run `ceph mon stat` to see which monitors are out of quorum.",
+	"MON_CLOCK_SKEW":     "Verify chrony/NTP synchronisation on all monitor hosts.",
+	"OSD_DOWN":           "Find the down OSDs with `ceph osd tree down` and inspect their daemon logs.",
+	"OSD_NEARFULL":       "Add capacity or rebalance data before OSDs reach the full ratio.",
+	"OSD_FULL":           "Writes are blocked: add capacity or delete data, then check `ceph osd df`.",
+	"PG_AVAILABILITY":    "Inspect inactive PGs with `ceph pg dump_stuck inactive`.",
+	"PG_DEGRADED":        "Wait for recovery to finish or bring missing OSDs back online.",
+	"POOL_NO_REDUNDANCY": "Increase the pool size to at least 2 replicas.",
+	"RECENT_CRASH":       "Review crashes with `ceph crash ls-new` and archive them once handled.",
+}
+
+// healthChecksRule turns every unmuted health check into a finding.
+type healthChecksRule struct{}
+
+func (healthChecksRule) ID() string {
+	return healthChecksRuleID
+}
+
+func (r healthChecksRule) Check(status *domain.CephStatus) []Finding {
+	findings := make([]Finding, 0, len(status.Health.Checks))
+
+	for _, check := range status.Health.Checks {
+		if check.Muted {
+			continue
+		}
+
+		remediation, ok := healthCheckRemediations[check.Code]
+		if !ok {
+			remediation = defaultHealthRemediation
+		}
+
+		findings = append(findings, Finding{
+			RuleID:      r.ID(),
+			Severity:    severityOfHealth(check.Severity),
+			Summary:     fmt.Sprintf("health check %s is raised", check.Code),
+			Evidence:    []string{fmt.Sprintf(healthCheckEvidenceMessage, check.Code, check.Message)},
+			Remediation: remediation,
+		})
+	}
+
+	return findings
+}
+
+func severityOfHealth(status domain.HealthStatus) Severity {
+	switch status {
+	case domain.HealthErr:
+		return SeverityCritical
+	case domain.HealthWarn:
+		return SeverityWarning
+	case domain.HealthOK:
+		return SeverityInfo
+	default:
+		return SeverityWarning
+	}
+}
@@ -0,0 +1,40 @@
+package diagnosis
+
+import (
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+const managerRuleID = "mgr-available"
+
+// managerRule reports a missing active manager or a lack of standbys.
+type managerRule struct{}
+
+func (managerRule) ID() string {
+	return managerRuleID
+}
+
+func (r managerRule) Check(status *domain.CephStatus) []Finding {
+	if !status.MgrMap.Available {
+		return []Finding{{
+			RuleID:      r.ID(),
+			Severity:    SeverityCritical,
+			Summary:     "no active manager daemon",
+			Evidence:    []string{"mgrmap.available=false"},
+			Remediation: "Start a ceph-mgr daemon; PG statistics and orchestration are unavailable without one.",
+		}}
+	}
+
+	if status.MgrMap.NumStandbys == 0 {
+		return []Finding{{
+			RuleID:      r.ID(),
+			Severity:    SeverityInfo,
+			Summary:     "no standby manager daemon",
+			Evidence:    []string{fmt.Sprintf("mgrmap.num_standbys=%d", status.MgrMap.NumStandbys)},
+			Remediation: "Deploy a second ceph-mgr so a failover target exists.",
+		}}
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package diagnosis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+const monitorQuorumRuleID = "mon-quorum"
+
+// monitorQuorumRule reports monitors that are missing from the quorum.
+type monitorQuorumRule struct{}
+
+func (monitorQuorumRule) ID() string {
+	return monitorQuorumRuleID
+}
+
+func (r monitorQuorumRule) Check(status *domain.CephStatus) []Finding {
+	inQuorum := len(status.MonMap.QuorumNames)
+	total := status.MonMap.NumMons
+
+	if total == 0 || inQuorum >= total {
+		return nil
+	}
+
+	severity := SeverityWarning
+	if inQuorum <= total/2 {
+		severity = SeverityCritical
+	}
+
+	return []Finding{{
+		RuleID:   r.ID(),
+		Severity: severity,
+		Summary:  fmt.Sprintf("%d of %d monitors are out of quorum", total-inQuorum, total),
+		Evidence: []string{
+			fmt.Sprintf("monmap.num_mons=%d", total),
+			"quorum_names=" + strings.Join(status.MonMap.QuorumNames, ","),
+		},
+		Remediation: "Check the missing monitor daemons and network reachability between monitor hosts.",
+	}}
+}
@@ -0,0 +1,43 @@
+package diagnosis
+
+import (
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+const osdRuleID = "osd-state"
+
+// osdRule reports OSDs that are down or marked out.
+type osdRule struct{}
+
+func (osdRule) ID() string {
+	return osdRuleID
+}
+
+func (r osdRule) Check(status *domain.CephStatus) []Finding {
+	osdMap := status.OSDMap
+	findings := make([]Finding, 0)
+
+	if down := osdMap.NumOSDs - osdMap.NumUpOSDs; down > 0 {
+		findings = append(findings, Finding{
+			RuleID:      r.ID(),
+			Severity:    SeverityWarning,
+			Summary:     fmt.Sprintf("%d of %d OSDs are down", down, osdMap.NumOSDs),
+			Evidence:    []string{fmt.Sprintf("osdmap.num_osds=%d num_up_osds=%d", osdMap.NumOSDs, osdMap.NumUpOSDs)},
+			Remediation: "List them with `ceph osd tree down` and check the daemons and their disks.",
+		})
+	}
+
+	if out := osdMap.NumOSDs - osdMap.NumInOSDs; out > 0 {
+		findings = append(findings, Finding{
+			RuleID:      r.ID(),
+			Severity:    SeverityWarning,
+			Summary:     fmt.Sprintf("%d of %d OSDs are out", out, osdMap.NumOSDs),
+			Evidence:    []string{fmt.Sprintf("osdmap.num_osds=%d num_in_osds=%d", osdMap.NumOSDs, osdMap.NumInOSDs)},
+			Remediation: "Mark recovered OSDs back in with `ceph osd in <id>` once they are healthy.",
+		})
+	}
+
+	return findings
+}
@@ -0,0 +1,61 @@
+package diagnosis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+const (
+	placementGroupRuleID = "pg-state"
+	cleanPGState         = "active+clean"
+)
+
+// placementGroupRule reports placement groups that are not active+clean.
+type placementGroupRule struct{}
+
+func (placementGroupRule) ID() string {
+	return placementGroupRuleID
+}
+
+func (r placementGroupRule) Check(status *domain.CephStatus) []Finding {
+	evidence := make([]string, 0)
+	unclean := 0
+	inactive := 0
+
+	for _, state := range status.PGMap.States {
+		if state.State == cleanPGState {
+			continue
+		}
+
+		unclean += state.Count
+		evidence = append(evidence, fmt.Sprintf("%d %s", state.Count, state.State))
+
+		if !strings.Contains(state.State, "active") {
+			inactive += state.Count
+		}
+	}
+
+	if unclean == 0 {
+		return nil
+	}
+
+	if inactive > 0 {
+		return []Finding{{
+			RuleID:      r.ID(),
+			Severity:    SeverityCritical,
+			Summary:     fmt.Sprintf("%d of %d PGs are inactive", inactive, status.PGMap.NumPGs),
+			Evidence:    evidence,
+			Remediation: "I/O to inactive PGs is blocked; inspect them with `ceph pg dump_stuck inactive`.",
+		}}
+	}
+
+	return []Finding{{
+		RuleID:      r.ID(),
+		Severity:    SeverityWarning,
+		Summary:     fmt.Sprintf("%d of %d PGs are not active+clean", unclean, status.PGMap.NumPGs),
+		Evidence:    evidence,
+		Remediation: "Watch recovery progress with `ceph -w`; stuck PGs are listed by `ceph pg dump_stuck`.",
+	}}
+}
@@ -0,0 +1,76 @@
+package diagnosis_test
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain/diagnosis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRules_HealthyClusterHasNoFindings(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	status := healthyStatus()
+	engine := diagnosis.NewEngine(diagnosis.DefaultRules()...)
+
+	// Act
+	findings := engine.Diagnose(status)
+
+	// Assert
+	require.Empty(t, findings)
+}
+
+func TestDefaultRules_DegradedCluster(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	status := degradedStatus()
+	engine := diagnosis.NewEngine(diagnosis.DefaultRules()...)
+
+	// Act
+	findings := engine.Diagnose(status)
+
+	// Assert
+	require.Equal(t, []string{
+		"health-checks/warning/health check OSD_DOWN is raised",
+		"health-checks/warning/health check PG_DEGRADED is raised",
+		"osd-state/warning/1 of 6 OSDs are down",
+		"pg-state/warning/4 of 97 PGs are not active+clean",
+	}, describe(findings))
+	require.False(t, diagnosis.HasCritical(findings))
+	require.Equal(t, []string{"OSD_DOWN: 1 osds down"}, findings[0].Evidence)
+	require.NotEmpty(t, findings[0].Remediation)
+}
+
+func TestDefaultRules_OutageCluster(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	status := outageStatus()
+	engine := diagnosis.NewEngine(diagnosis.DefaultRules()...)
+
+	// Act
+	findings := engine.Diagnose(status)
+
+	// Assert
+	require.Equal(t, []string{
+		"capacity/critical/raw capacity is 89.6% used",
+		"health-checks/critical/health check PG_AVAILABILITY is raised",
+		"mgr-available/critical/no active manager daemon",
+		"mon-quorum/critical/2 of 3 monitors are out of quorum",
+		"pg-state/critical/8 of 64 PGs are inactive",
+		"health-checks/warning/health check MON_DOWN is raised",
+		"osd-state/warning/1 of 4 OSDs are out",
+	}, describe(findings))
+	require.True(t, diagnosis.HasCritical(findings))
+}
+
+func describe(findings []diagnosis.Finding) []string {
+	descriptions := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		descriptions = append(descriptions, finding.RuleID+"/"+string(finding.Severity)+"/"+finding.Summary)
+	}
+
+	return descriptions
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultEntity is the cephx user a cluster authenticates as unless another is set.
+const DefaultEntity = "client.admin"
+
+var ErrInvalidEntity = errors.New("invalid cephx entity")
+
+// ParseEntity validates a cephx client name such as client.cephdoctor.
+// The id is limited to letters, digits, '.', '_' and '-' so it is safe in file names and shells.
+func ParseEntity(value string) (string, error) {
+	id, ok := strings.CutPrefix(value, "client.")
+	if !ok || id == "" || strings.ContainsFunc(id, isInvalidEntityRune) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidEntity, value)
+	}
+
+	return value, nil
+}
+
+func isInvalidEntityRune(r rune) bool {
+	isAlnum := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+
+	return !isAlnum && r != '.' && r != '_' && r != '-'
+}
+
+// WithEntity sets the cephx user the cluster key belongs to.
+func WithEntity(entity string) ClusterOption {
+	return func(c *Cluster) error {
+		parsed, err := ParseEntity(entity)
+		if err != nil {
+			return err
+		}
+
+		c.entity = parsed
+
+		return nil
+	}
+}
+
+// Entity returns the cephx user the cluster key belongs to.
+func (c *Cluster) Entity() string {
+	return c.entity
+}
@@ -2,13 +2,17 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"unicode"
 )
 
 var (
 	ErrEmptyHosts    = errors.New("cluster hosts are empty")
 	ErrEmptyHost     = errors.New("cluster host is empty")
 	ErrDuplicateHost = errors.New("cluster host is duplicated")
+	ErrHostNotFound  = errors.New("cluster host not found")
+	ErrInvalidHost   = errors.New("cluster host contains control characters")
 )
 
 type Hosts struct {
@@ -28,6 +32,10 @@ func NewHosts(hosts []string) (*Hosts, error) {
 			return nil, ErrEmptyHost
 		}
 
+		if strings.ContainsFunc(host, unicode.IsControl) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidHost, host)
+		}
+
 		normalizedHost := normalizeHost(host)
 		if _, ok := seen[normalizedHost]; ok {
 			return nil, ErrDuplicateHost
@@ -53,3 +61,40 @@ func normalizeHost(host string) string {
 
 	return host + ":3300"
 }
+
+// EditHosts removes and then adds hosts, comparing entries after port normalization.
+// Removing a host that is not present fails with ErrHostNotFound; the result is validated by NewHosts.
+func EditHosts(current, add, remove []string) ([]string, error) {
+	removed := make(map[string]struct{}, len(remove))
+	for _, host := range remove {
+		removed[normalizeHost(strings.TrimSpace(host))] = struct{}{}
+	}
+
+	edited := make([]string, 0, len(current)+len(add))
+
+	for _, host := range current {
+		normalized := normalizeHost(host)
+		if _, ok := removed[normalized]; ok {
+			delete(removed, normalized)
+
+			continue
+		}
+
+		edited = append(edited, host)
+	}
+
+	for _, host := range remove {
+		if _, ok := removed[normalizeHost(strings.TrimSpace(host))]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrHostNotFound, host)
+		}
+	}
+
+	edited = append(edited, add...)
+
+	hosts, err := NewHosts(edited)
+	if err != nil {
+		return nil, err
+	}
+
+	return hosts.Values(), nil
+}
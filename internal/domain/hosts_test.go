@@ -80,3 +80,53 @@ func TestHosts_ValuesReturnsCopy(t *testing.T) {
 	// Assert
 	require.Equal(t, want, hosts.Values())
 }
+
+func TestEditHosts(t *testing.T) {
+	t.Parallel()
+
+	current := []string{"10.0.0.1:3300", "10.0.0.2:3300"}
+
+	t.Run("add and remove with normalization", func(t *testing.T) {
+		t.Parallel()
+
+		// Act
+		hosts, err := domain.EditHosts(current, []string{"10.0.0.3"}, []string{" 10.0.0.1 "})
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, []string{"10.0.0.2:3300", "10.0.0.3:3300"}, hosts)
+	})
+
+	t.Run("remove unknown host", func(t *testing.T) {
+		t.Parallel()
+
+		// Act
+		hosts, err := domain.EditHosts(current, nil, []string{"10.0.0.9"})
+
+		// Assert
+		require.ErrorIs(t, err, domain.ErrHostNotFound)
+		require.Nil(t, hosts)
+	})
+
+	t.Run("add duplicate host", func(t *testing.T) {
+		t.Parallel()
+
+		// Act
+		hosts, err := domain.EditHosts(current, []string{"10.0.0.2"}, nil)
+
+		// Assert
+		require.ErrorIs(t, err, domain.ErrDuplicateHost)
+		require.Nil(t, hosts)
+	})
+
+	t.Run("remove every host", func(t *testing.T) {
+		t.Parallel()
+
+		// Act
+		hosts, err := domain.EditHosts(current, nil, current)
+
+		// Assert
+		require.ErrorIs(t, err, domain.ErrEmptyHosts)
+		require.Nil(t, hosts)
+	})
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode"
+)
+
+// Key reference schemes. A key such as env:CEPH_KEY_PROD names where the cephx key lives
+// instead of holding it; cephx keys are base64 and never contain a colon.
+const (
+	KeySchemeEnv  = "env"
+	KeySchemeFile = "file"
+	KeySchemeExec = "exec"
+
+	redactedKey = "[REDACTED]"
+)
+
+var (
+	ErrInvalidKeyReference = errors.New("invalid key reference")
+	ErrInvalidClusterKey   = errors.New("cluster key contains control characters")
+)
+
+// KeyResolver returns a copy of the cluster whose key reference is replaced by the key it points at.
+// Clusters holding a literal key are returned unchanged.
+type KeyResolver interface {
+	ResolveKey(ctx context.Context, cluster *Cluster) (*Cluster, error)
+}
+
+// ParseKeyReference splits key into scheme and target when it is a reference.
+func ParseKeyReference(key string) (string, string, bool) {
+	scheme, target, ok := strings.Cut(key, ":")
+	if !ok {
+		return "", "", false
+	}
+
+	switch scheme {
+	case KeySchemeEnv, KeySchemeFile, KeySchemeExec:
+		return scheme, target, true
+	default:
+		return "", "", false
+	}
+}
+
+// validateKey rejects control characters, which would let a key break out of the files it is written to,
+// and references without a target.
+func validateKey(key string) error {
+	if strings.ContainsFunc(key, unicode.IsControl) {
+		return ErrInvalidClusterKey
+	}
+
+	scheme, target, ok := ParseKeyReference(key)
+	if ok && strings.TrimSpace(target) == "" {
+		return fmt.Errorf("%w: %s has no target", ErrInvalidKeyReference, scheme)
+	}
+
+	return nil
+}
+
+// KeyIsReference reports whether the key must be resolved before use.
+func (c *Cluster) KeyIsReference() bool {
+	_, _, ok := ParseKeyReference(c.key)
+
+	return ok
+}
+
+// RedactedKey returns the key reference, which is safe to show, or a placeholder for a literal key.
+func (c *Cluster) RedactedKey() string {
+	if c.KeyIsReference() {
+		return c.key
+	}
+
+	return redactedKey
+}
+
+// String identifies the cluster by name so formatting it never prints the key.
+func (c *Cluster) String() string {
+	return c.name
+}
+
+// GoString keeps %#v from dumping the key.
+func (c *Cluster) GoString() string {
+	return fmt.Sprintf("domain.Cluster{name: %q, key: %q}", c.name, c.RedactedKey())
+}
+
+// LogValue logs the cluster without its key.
+func (c *Cluster) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("name", c.name),
+		slog.Any("hosts", c.Hosts()),
+		slog.String("entity", c.entity),
+		slog.String("key", c.RedactedKey()),
+	)
+}
@@ -0,0 +1,92 @@
+package domain_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeyReference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		key    string
+		scheme string
+		target string
+		ok     bool
+	}{
+		{key: "env:CEPH_KEY_PROD", scheme: domain.KeySchemeEnv, target: "CEPH_KEY_PROD", ok: true},
+		{key: "file:/run/secrets/prod.key", scheme: domain.KeySchemeFile, target: "/run/secrets/prod.key", ok: true},
+		{key: "exec:pass show ceph/prod", scheme: domain.KeySchemeExec, target: "pass show ceph/prod", ok: true},
+		{key: "AQBexample==", scheme: "", target: "", ok: false},
+		{key: "vault:secret/prod", scheme: "", target: "", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			scheme, target, ok := domain.ParseKeyReference(test.key)
+
+			// Assert
+			require.Equal(t, test.scheme, scheme)
+			require.Equal(t, test.target, target)
+			require.Equal(t, test.ok, ok)
+		})
+	}
+}
+
+func TestNewCluster_RejectsEmptyKeyReference(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	_, err := domain.NewCluster("alpha", "env: ", []string{"10.0.0.1"})
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrInvalidKeyReference)
+}
+
+func TestNewCluster_RejectsControlCharacters(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	hostile := "AQBsecret\nCEPHDOCTOR_EOF\ntouch /tmp/pwned\n"
+
+	// Act
+	_, keyErr := domain.NewCluster("alpha", hostile, []string{"10.0.0.1"})
+	_, hostErr := domain.NewCluster("alpha", "secret", []string{hostile})
+
+	// Assert
+	require.ErrorIs(t, keyErr, domain.ErrInvalidClusterKey)
+	require.ErrorIs(t, hostErr, domain.ErrInvalidHost)
+}
+
+func TestCluster_RedactsLiteralKey(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	literal, err := domain.NewCluster("alpha", "AQBsecret==", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	reference, err := domain.NewCluster("beta", "env:CEPH_KEY_BETA", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	var logs bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	// Act
+	logger.Info("cluster", "cluster", literal, "other", reference)
+	formatted := fmt.Sprintf("%v %+v %#v", literal, literal, literal)
+
+	// Assert
+	require.NotContains(t, logs.String(), "AQBsecret")
+	require.Contains(t, logs.String(), "cluster.key=[REDACTED]")
+	require.Contains(t, logs.String(), "other.key=env:CEPH_KEY_BETA")
+	require.NotContains(t, formatted, "AQBsecret")
+}
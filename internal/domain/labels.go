@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidLabel = errors.New("invalid label")
+
+// ParseLabels parses key=value items such as env=prod. A later item wins over an earlier one
+// with the same key.
+func ParseLabels(items []string) (map[string]string, error) {
+	labels := make(map[string]string, len(items))
+
+	for _, item := range items {
+		key, value, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not in key=value format", ErrInvalidLabel, item)
+		}
+
+		labels[key] = value
+	}
+
+	err := validateLabels(labels)
+	if err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+// WithLabels replaces the cluster labels. A nil or empty map clears them.
+func WithLabels(labels map[string]string) ClusterOption {
+	return func(c *Cluster) error {
+		err := validateLabels(labels)
+		if err != nil {
+			return err
+		}
+
+		c.labels = maps.Clone(labels)
+
+		return nil
+	}
+}
+
+// Labels returns a copy of the cluster labels.
+func (c *Cluster) Labels() map[string]string {
+	labels := maps.Clone(c.labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	return labels
+}
+
+// FormatLabels renders labels as comma-separated key=value pairs sorted by key.
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, key+"="+labels[key])
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+// validateLabels requires non-empty keys and limits keys and values to letters, digits,
+// '.', '_', '-' and, in keys, '/' so they can be written in selectors without quoting.
+func validateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		err := validateLabel(key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateLabel(key, value string) error {
+	if key == "" || strings.ContainsFunc(key, isInvalidLabelKeyRune) {
+		return fmt.Errorf("%w: key %q", ErrInvalidLabel, key)
+	}
+
+	if strings.ContainsFunc(value, isInvalidEntityRune) {
+		return fmt.Errorf("%w: value %q for key %q", ErrInvalidLabel, value, key)
+	}
+
+	return nil
+}
+
+func isInvalidLabelKeyRune(r rune) bool {
+	return r != '/' && isInvalidEntityRune(r)
+}
@@ -0,0 +1,39 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testCancelledContext(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+	require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, "alpha", "secret")))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, listErr := repo.ListClusters(ctx)
+
+	require.ErrorIs(t, repo.CreateCluster(ctx, newCluster(t, "beta", "secret")), context.Canceled)
+	require.ErrorIs(t, repo.UpdateCluster(ctx, newCluster(t, "alpha", "rotated")), context.Canceled)
+	require.ErrorIs(t, repo.RenameCluster(ctx, "alpha", newCluster(t, "omega", "secret")), context.Canceled)
+	require.ErrorIs(t, repo.DeleteCluster(ctx, "alpha"), context.Canceled)
+	require.ErrorIs(t, repo.RecordCephVersion(ctx, "alpha", "18.2.7"), context.Canceled)
+	require.ErrorIs(t, listErr, context.Canceled)
+
+	clusters := listClusters(t, repo)
+	require.Equal(t, []string{"alpha"}, clusterNames(clusters))
+	require.Equal(t, "secret", clusters[0].Key())
+}
+
+func testNilCluster(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+	require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, "alpha", "secret")))
+
+	require.Error(t, repo.CreateCluster(t.Context(), nil))
+	require.Error(t, repo.UpdateCluster(t.Context(), nil))
+	require.Error(t, repo.RenameCluster(t.Context(), "alpha", nil))
+	require.Equal(t, []string{"alpha"}, clusterNames(listClusters(t, repo)))
+}
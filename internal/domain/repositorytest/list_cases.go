@@ -0,0 +1,39 @@
+package repositorytest
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func testListSortsByName(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+	require.Empty(t, listClusters(t, repo))
+
+	for _, name := range []string{"charlie", "alpha", "Bravo", "alpha-2"} {
+		require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, name, "secret")))
+	}
+
+	require.Equal(t, []string{"Bravo", "alpha", "alpha-2", "charlie"}, clusterNames(listClusters(t, repo)))
+}
+
+func testPersistsOptionalFields(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+
+	target, err := domain.ParseSSHTarget("ceph@admin-1:2222")
+	require.NoError(t, err)
+
+	cluster := newCluster(t, "alpha", "env:ALPHA_KEY", domain.WithEntity("client.cephdoctor"),
+		domain.WithBackend(domain.BackendSSH), domain.WithSSHTarget(target),
+		domain.WithLabels(map[string]string{"env": "prod", "dc": "seoul"}), domain.WithCephVersion("17.2.8"))
+	require.NoError(t, repo.CreateCluster(t.Context(), cluster))
+
+	stored := listClusters(t, repo)[0]
+	require.Equal(t, "env:ALPHA_KEY", stored.Key())
+	require.Equal(t, "client.cephdoctor", stored.Entity())
+	require.Equal(t, domain.BackendSSH, stored.Backend())
+	require.Equal(t, "ceph@admin-1:2222", stored.SSHTarget().String())
+	require.Equal(t, map[string]string{"env": "prod", "dc": "seoul"}, stored.Labels())
+	require.Equal(t, "17.2.8", stored.CephVersion())
+}
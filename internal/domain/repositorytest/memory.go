@@ -0,0 +1,77 @@
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// MemoryRepository is a complete in-memory domain.ClusterRepository for command tests.
+// Clusters are immutable, so it stores and returns the pointers it is given.
+type MemoryRepository struct {
+	mu       sync.Mutex
+	clusters map[string]*domain.Cluster
+}
+
+// NewMemoryRepository returns a repository holding clusters. Later clusters replace
+// earlier ones with the same name.
+func NewMemoryRepository(clusters ...*domain.Cluster) *MemoryRepository {
+	repo := &MemoryRepository{mu: sync.Mutex{}, clusters: make(map[string]*domain.Cluster, len(clusters))}
+	for _, cluster := range clusters {
+		repo.clusters[cluster.Name()] = cluster
+	}
+
+	return repo
+}
+
+func (r *MemoryRepository) ListClusters(ctx context.Context) ([]*domain.Cluster, error) {
+	err := checkContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clusters := make([]*domain.Cluster, 0, len(r.clusters))
+	for _, cluster := range r.clusters {
+		clusters = append(clusters, cluster)
+	}
+
+	slices.SortFunc(clusters, func(a, b *domain.Cluster) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	return clusters, nil
+}
+
+func (r *MemoryRepository) DeleteCluster(ctx context.Context, name string) error {
+	err := checkContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.clusters[name]; !ok {
+		return domain.ErrClusterNotFound
+	}
+
+	delete(r.clusters, name)
+
+	return nil
+}
+
+func checkContext(ctx context.Context) error {
+	err := ctx.Err()
+	if err != nil {
+		return fmt.Errorf("context done: %w", err)
+	}
+
+	return nil
+}
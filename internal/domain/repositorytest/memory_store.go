@@ -0,0 +1,79 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+var errNilCluster = errors.New("cluster is nil")
+
+func (r *MemoryRepository) CreateCluster(ctx context.Context, cluster *domain.Cluster) error {
+	return r.store(ctx, "", cluster)
+}
+
+func (r *MemoryRepository) UpdateCluster(ctx context.Context, cluster *domain.Cluster) error {
+	if cluster == nil {
+		return errNilCluster
+	}
+
+	return r.store(ctx, cluster.Name(), cluster)
+}
+
+func (r *MemoryRepository) RenameCluster(ctx context.Context, oldName string, cluster *domain.Cluster) error {
+	return r.store(ctx, oldName, cluster)
+}
+
+func (r *MemoryRepository) RecordCephVersion(ctx context.Context, name, version string) error {
+	err := checkContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cluster, ok := r.clusters[name]
+	if !ok {
+		return domain.ErrClusterNotFound
+	}
+
+	updated, err := cluster.Edited(cluster.Name(), cluster.Key(), cluster.Hosts(), domain.WithCephVersion(version))
+	if err != nil {
+		return fmt.Errorf("record ceph version: %w", err)
+	}
+
+	r.clusters[name] = updated
+
+	return nil
+}
+
+// store saves cluster in place of the cluster named oldName, or as a new cluster when oldName is empty.
+func (r *MemoryRepository) store(ctx context.Context, oldName string, cluster *domain.Cluster) error {
+	err := checkContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cluster == nil {
+		return errNilCluster
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.clusters[oldName]; oldName != "" && !ok {
+		return domain.ErrClusterNotFound
+	}
+
+	if _, ok := r.clusters[cluster.Name()]; ok && cluster.Name() != oldName {
+		return domain.ErrClusterAlreadyExists
+	}
+
+	delete(r.clusters, oldName)
+	r.clusters[cluster.Name()] = cluster
+
+	return nil
+}
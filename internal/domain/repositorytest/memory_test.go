@@ -0,0 +1,35 @@
+package repositorytest_test
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/repositorytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRepository_Conformance(t *testing.T) {
+	t.Parallel()
+
+	repositorytest.Run(t, func(*testing.T) domain.ClusterRepository {
+		return repositorytest.NewMemoryRepository()
+	})
+}
+
+func TestNewMemoryRepository_ReturnsGivenClusters(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	zeta, err := domain.NewCluster("zeta", "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	alpha, err := domain.NewCluster("alpha", "secret", []string{"10.0.0.2"})
+	require.NoError(t, err)
+
+	// Act
+	clusters, err := repositorytest.NewMemoryRepository(zeta, alpha).ListClusters(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []*domain.Cluster{alpha, zeta}, clusters)
+}
@@ -0,0 +1,53 @@
+package repositorytest
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func testRenameMovesCluster(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+	require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, "alpha", "secret")))
+
+	require.NoError(t, repo.RenameCluster(t.Context(), "alpha", newCluster(t, "omega", "rotated")))
+
+	clusters := listClusters(t, repo)
+	require.Equal(t, []string{"omega"}, clusterNames(clusters))
+	require.Equal(t, "rotated", clusters[0].Key())
+}
+
+func testRenameMissing(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+
+	err := repo.RenameCluster(t.Context(), "alpha", newCluster(t, "omega", "secret"))
+
+	require.ErrorIs(t, err, domain.ErrClusterNotFound)
+	require.Empty(t, listClusters(t, repo))
+}
+
+func testRenameOntoExistingCluster(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+	require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, "alpha", "alpha-secret")))
+	require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, "omega", "omega-secret")))
+
+	err := repo.RenameCluster(t.Context(), "alpha", newCluster(t, "omega", "alpha-secret"))
+
+	require.ErrorIs(t, err, domain.ErrClusterAlreadyExists)
+
+	clusters := listClusters(t, repo)
+	require.Equal(t, []string{"alpha", "omega"}, clusterNames(clusters))
+	require.Equal(t, "omega-secret", clusters[1].Key())
+}
+
+func testRenameToSameName(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+	require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, "alpha", "secret")))
+
+	require.NoError(t, repo.RenameCluster(t.Context(), "alpha", newCluster(t, "alpha", "rotated")))
+
+	clusters := listClusters(t, repo)
+	require.Equal(t, []string{"alpha"}, clusterNames(clusters))
+	require.Equal(t, "rotated", clusters[0].Key())
+}
@@ -0,0 +1,74 @@
+// Package repositorytest holds the behaviour every domain.ClusterRepository implementation
+// must share. Implementations run it from their own tests with Run, and command tests use
+// MemoryRepository in place of a real repository.
+package repositorytest
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+// Factory returns an empty repository owned by t.
+type Factory func(t *testing.T) domain.ClusterRepository
+
+// Run checks the repository returned by newRepository against the shared contract.
+// Each case runs in parallel on its own repository.
+func Run(t *testing.T, newRepository Factory) {
+	t.Helper()
+
+	cases := map[string]func(*testing.T, Factory){
+		"CreateThenList":            testCreateThenList,
+		"CreateDuplicate":           testCreateDuplicate,
+		"UpdateReplacesCluster":     testUpdateReplacesCluster,
+		"UpdateMissing":             testUpdateMissing,
+		"DeleteRemovesCluster":      testDeleteRemovesCluster,
+		"DeleteMissing":             testDeleteMissing,
+		"ListSortsByName":           testListSortsByName,
+		"PersistsOptionalFields":    testPersistsOptionalFields,
+		"RenameMovesCluster":        testRenameMovesCluster,
+		"RenameMissing":             testRenameMissing,
+		"RenameOntoExistingCluster": testRenameOntoExistingCluster,
+		"RenameToSameName":          testRenameToSameName,
+		"RecordCephVersion":         testRecordCephVersionKeepsOtherFields,
+		"RecordCephVersionInvalid":  testRecordCephVersionRejectsInvalid,
+		"CancelledContext":          testCancelledContext,
+		"NilCluster":                testNilCluster,
+	}
+
+	for name, run := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			run(t, newRepository)
+		})
+	}
+}
+
+func newCluster(t *testing.T, name, key string, opts ...domain.ClusterOption) *domain.Cluster {
+	t.Helper()
+
+	cluster, err := domain.NewCluster(name, key, []string{"10.0.0.1", "10.0.0.2:6789"}, opts...)
+	require.NoError(t, err)
+
+	return cluster
+}
+
+func listClusters(t *testing.T, repo domain.ClusterRepository) []*domain.Cluster {
+	t.Helper()
+
+	clusters, err := repo.ListClusters(t.Context())
+	require.NoError(t, err)
+
+	return clusters
+}
+
+func clusterNames(clusters []*domain.Cluster) []string {
+	names := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		names = append(names, cluster.Name())
+	}
+
+	return names
+}
@@ -0,0 +1,39 @@
+package repositorytest
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecordCephVersionKeepsOtherFields(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+	require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, "alpha", "secret")))
+
+	// The cluster is edited after the caller read it; recording must not undo the edit.
+	edited := newCluster(t, "alpha", "rotated", domain.WithLabels(map[string]string{"env": "prod"}))
+	require.NoError(t, repo.UpdateCluster(t.Context(), edited))
+
+	require.NoError(t, repo.RecordCephVersion(t.Context(), "alpha", "18.2.7"))
+
+	recorded := listClusters(t, repo)[0]
+	require.Equal(t, "18.2.7", recorded.CephVersion())
+	require.Equal(t, "rotated", recorded.Key())
+	require.Equal(t, map[string]string{"env": "prod"}, recorded.Labels())
+
+	require.NoError(t, repo.RecordCephVersion(t.Context(), "alpha", ""))
+	require.Empty(t, listClusters(t, repo)[0].CephVersion())
+}
+
+func testRecordCephVersionRejectsInvalid(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+	require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, "alpha", "secret")))
+
+	missingErr := repo.RecordCephVersion(t.Context(), "omega", "18.2.7")
+	invalidErr := repo.RecordCephVersion(t.Context(), "alpha", "v18")
+
+	require.ErrorIs(t, missingErr, domain.ErrClusterNotFound)
+	require.ErrorIs(t, invalidErr, domain.ErrInvalidCephVersion)
+	require.Empty(t, listClusters(t, repo)[0].CephVersion())
+}
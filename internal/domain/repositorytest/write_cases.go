@@ -0,0 +1,75 @@
+package repositorytest
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func testCreateThenList(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+
+	require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, "alpha", "secret")))
+
+	clusters := listClusters(t, repo)
+	require.Len(t, clusters, 1)
+	require.Equal(t, "alpha", clusters[0].Name())
+	require.Equal(t, "secret", clusters[0].Key())
+	require.Equal(t, []string{"10.0.0.1:3300", "10.0.0.2:6789"}, clusters[0].Hosts())
+	require.Equal(t, domain.DefaultEntity, clusters[0].Entity())
+	require.Empty(t, clusters[0].Labels())
+	require.Empty(t, clusters[0].CephVersion())
+}
+
+func testCreateDuplicate(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+	require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, "alpha", "secret")))
+
+	err := repo.CreateCluster(t.Context(), newCluster(t, "alpha", "other"))
+
+	require.ErrorIs(t, err, domain.ErrClusterAlreadyExists)
+	require.Equal(t, "secret", listClusters(t, repo)[0].Key())
+}
+
+func testUpdateReplacesCluster(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+	require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, "alpha", "secret")))
+
+	updated, err := domain.NewCluster("alpha", "rotated", []string{"10.0.0.9"})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.UpdateCluster(t.Context(), updated))
+
+	clusters := listClusters(t, repo)
+	require.Len(t, clusters, 1)
+	require.Equal(t, "rotated", clusters[0].Key())
+	require.Equal(t, []string{"10.0.0.9:3300"}, clusters[0].Hosts())
+}
+
+func testUpdateMissing(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+
+	err := repo.UpdateCluster(t.Context(), newCluster(t, "alpha", "secret"))
+
+	require.ErrorIs(t, err, domain.ErrClusterNotFound)
+	require.Empty(t, listClusters(t, repo))
+}
+
+func testDeleteRemovesCluster(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+	require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, "alpha", "secret")))
+	require.NoError(t, repo.CreateCluster(t.Context(), newCluster(t, "beta", "secret")))
+
+	require.NoError(t, repo.DeleteCluster(t.Context(), "alpha"))
+
+	require.Equal(t, []string{"beta"}, clusterNames(listClusters(t, repo)))
+}
+
+func testDeleteMissing(t *testing.T, newRepository Factory) {
+	repo := newRepository(t)
+
+	err := repo.DeleteCluster(t.Context(), "alpha")
+
+	require.ErrorIs(t, err, domain.ErrClusterNotFound)
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+)
+
+var ErrInvalidSelector = errors.New("invalid label selector")
+
+type selectorOperator int
+
+const (
+	operatorExists selectorOperator = iota
+	operatorNotExists
+	operatorEquals
+	operatorNotEquals
+	operatorIn
+	operatorNotIn
+)
+
+// Selector matches cluster labels against set-based requirements such as
+// "env=prod,dc in (seoul,busan),!canary". Every requirement must hold.
+type Selector struct {
+	requirements []requirement
+}
+
+type requirement struct {
+	key      string
+	operator selectorOperator
+	values   []string
+}
+
+// Empty reports whether the selector has no requirements and so matches every cluster.
+func (s Selector) Empty() bool {
+	return len(s.requirements) == 0
+}
+
+// Matches reports whether labels satisfy every requirement of the selector.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, req := range s.requirements {
+		if !req.matches(labels) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r requirement) matches(labels map[string]string) bool {
+	value, ok := labels[r.key]
+
+	switch r.operator {
+	case operatorExists:
+		return ok
+	case operatorNotExists:
+		return !ok
+	case operatorEquals, operatorIn:
+		return ok && slices.Contains(r.values, value)
+	case operatorNotEquals, operatorNotIn:
+		return !ok || !slices.Contains(r.values, value)
+	}
+
+	return false
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//nolint:gochecknoglobals // Compiled once; the pattern is fixed.
+var setRequirementPattern = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+
+// ParseSelector parses comma-separated requirements in the forms key, !key, key=value,
+// key==value, key!=value, key in (a,b) and key notin (a,b). An empty expression matches everything.
+func ParseSelector(expression string) (Selector, error) {
+	parts, err := splitRequirements(expression)
+	if err != nil {
+		return Selector{requirements: nil}, err
+	}
+
+	requirements := make([]requirement, 0, len(parts))
+
+	for _, part := range parts {
+		req, err := parseRequirement(part)
+		if err != nil {
+			return Selector{requirements: nil}, err
+		}
+
+		requirements = append(requirements, req)
+	}
+
+	return Selector{requirements: requirements}, nil
+}
+
+// splitRequirements splits expression on the commas outside parentheses.
+func splitRequirements(expression string) ([]string, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, nil
+	}
+
+	var parts []string
+
+	depth, start := 0, 0
+
+	for i, r := range expression {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(expression[start:i]))
+			start = i + 1
+		}
+
+		if depth < 0 || depth > 1 {
+			return nil, fmt.Errorf("%w: unbalanced parentheses in %q", ErrInvalidSelector, expression)
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("%w: unbalanced parentheses in %q", ErrInvalidSelector, expression)
+	}
+
+	return append(parts, strings.TrimSpace(expression[start:])), nil
+}
+
+func parseRequirement(part string) (requirement, error) {
+	req := requirement{key: part, operator: operatorExists, values: nil}
+
+	if match := setRequirementPattern.FindStringSubmatch(part); match != nil {
+		req.key, req.operator = match[1], operatorIn
+		if match[2] == "notin" {
+			req.operator = operatorNotIn
+		}
+
+		req.values = strings.Split(match[3], ",")
+	} else if key, value, ok := strings.Cut(part, "!="); ok {
+		req.key, req.operator, req.values = key, operatorNotEquals, []string{value}
+	} else if key, value, ok := strings.Cut(part, "="); ok {
+		req.key, req.operator, req.values = key, operatorEquals, []string{strings.TrimPrefix(value, "=")}
+	} else if key, ok := strings.CutPrefix(part, "!"); ok {
+		req.key, req.operator = key, operatorNotExists
+	}
+
+	req.key = strings.TrimSpace(req.key)
+	err := validateLabel(req.key, "")
+
+	for i := range req.values {
+		req.values[i] = strings.TrimSpace(req.values[i])
+		err = errors.Join(err, validateLabel(req.key, req.values[i]))
+	}
+
+	if err != nil {
+		return requirement{}, fmt.Errorf("%w: %q", ErrInvalidSelector, part)
+	}
+
+	return req, nil
+}
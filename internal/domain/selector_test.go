@@ -0,0 +1,116 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelector_Matches(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"env": "prod", "dc": "seoul"}
+	cases := map[string]bool{
+		"":                           true,
+		"env=prod":                   true,
+		"env==prod,dc=seoul":         true,
+		"env=dev":                    false,
+		"env!=dev":                   true,
+		"tier!=db":                   true,
+		"dc in (seoul, busan)":       true,
+		"dc notin (seoul,busan)":     false,
+		"env=prod,dc in (busan)":     false,
+		"env":                        true,
+		"!canary":                    true,
+		"!env":                       false,
+		"example.com/team notin (x)": true,
+	}
+
+	for expression, want := range cases {
+		t.Run(expression, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			selector, err := domain.ParseSelector(expression)
+			require.NoError(t, err)
+
+			// Act
+			matched := selector.Matches(labels)
+
+			// Assert
+			require.Equal(t, want, matched)
+		})
+	}
+}
+
+func TestParseSelector_RejectsInvalidExpressions(t *testing.T) {
+	t.Parallel()
+
+	for _, expression := range []string{"env=prod,", "dc in (seoul", "dc in ((a))", "env=pr od", "=prod", "env=prod)"} {
+		t.Run(expression, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			_, err := domain.ParseSelector(expression)
+
+			// Assert
+			require.ErrorIs(t, err, domain.ErrInvalidSelector)
+		})
+	}
+}
+
+func TestSelectClusters_FiltersByNameAndSelector(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	prod := newLabeledCluster(t, "alpha", map[string]string{"env": "prod"})
+	dev := newLabeledCluster(t, "beta", map[string]string{"env": "dev"})
+	other := newLabeledCluster(t, "gamma", map[string]string{"env": "prod"})
+
+	selector, err := domain.ParseSelector("env=prod")
+	require.NoError(t, err)
+
+	// Act
+	selected, err := domain.SelectClusters([]*domain.Cluster{prod, dev, other}, []string{"gamma", "beta"}, selector)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []*domain.Cluster{other}, selected)
+}
+
+func TestSelectClusters_UnknownName(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	alpha := newLabeledCluster(t, "alpha", nil)
+
+	// Act
+	selected, err := domain.SelectClusters([]*domain.Cluster{alpha}, []string{"missing"}, domain.Selector{})
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrClusterNotFound)
+	require.Nil(t, selected)
+}
+
+func TestParseLabels_RejectsInvalidItems(t *testing.T) {
+	t.Parallel()
+
+	for _, item := range []string{"env", "=prod", "env=pr od", "env=prod,dc"} {
+		// Act
+		labels, err := domain.ParseLabels([]string{item})
+
+		// Assert
+		require.ErrorIs(t, err, domain.ErrInvalidLabel, item)
+		require.Nil(t, labels)
+	}
+}
+
+func newLabeledCluster(t *testing.T, name string, labels map[string]string) *domain.Cluster {
+	t.Helper()
+
+	cluster, err := domain.NewCluster(name, "secret", []string{"10.0.0.1"}, domain.WithLabels(labels))
+	require.NoError(t, err)
+
+	return cluster
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"net"
+	"strconv"
+)
+
+const defaultSSHPort = 22
+
+var (
+	ErrEmptySSHTarget   = errors.New("ssh target is empty")
+	ErrInvalidSSHTarget = errors.New("ssh target is invalid")
+)
+
+// SSHTarget is the admin node that ceph commands are run on over SSH.
+type SSHTarget struct {
+	user string
+	host string
+	port int
+}
+
+func (t *SSHTarget) User() string {
+	return t.user
+}
+
+func (t *SSHTarget) Host() string {
+	return t.host
+}
+
+func (t *SSHTarget) Port() int {
+	return t.port
+}
+
+// Address returns host:port suitable for dialing.
+func (t *SSHTarget) Address() string {
+	return net.JoinHostPort(t.host, strconv.Itoa(t.port))
+}
+
+// String returns the target in user@host:port form, which ParseSSHTarget accepts.
+func (t *SSHTarget) String() string {
+	return t.user + "@" + t.Address()
+}
+
+// WithSSHTarget sets the admin node used by the ssh backend. A nil target clears it.
+func WithSSHTarget(target *SSHTarget) ClusterOption {
+	return func(c *Cluster) error {
+		c.ssh = target
+
+		return nil
+	}
+}
+
+// SSHTarget returns the admin node for the ssh backend, or nil when none is set.
+func (c *Cluster) SSHTarget() *SSHTarget {
+	return c.ssh
+}
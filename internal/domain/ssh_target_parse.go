@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ParseSSHTarget parses user@host[:port]. An IPv6 host with a port must be bracketed.
+func ParseSSHTarget(value string) (*SSHTarget, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, ErrEmptySSHTarget
+	}
+
+	user, address, ok := strings.Cut(value, "@")
+	if !ok || user == "" || address == "" {
+		return nil, fmt.Errorf("%w: %s: expected user@host[:port]", ErrInvalidSSHTarget, value)
+	}
+
+	host, portText, err := net.SplitHostPort(address)
+	if err != nil {
+		host, ok = hostWithoutPort(address)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidSSHTarget, value, err)
+		}
+
+		return &SSHTarget{user: user, host: host, port: defaultSSHPort}, nil
+	}
+
+	port, err := strconv.Atoi(portText)
+	if err != nil || port <= 0 || port > 65535 {
+		return nil, fmt.Errorf("%w: %s: bad port", ErrInvalidSSHTarget, value)
+	}
+
+	return &SSHTarget{user: user, host: host, port: port}, nil
+}
+
+// hostWithoutPort accepts a host name without a port, or an IPv6 literal with or without brackets.
+func hostWithoutPort(address string) (string, bool) {
+	if !strings.ContainsAny(address, ":[]") {
+		return address, true
+	}
+
+	if inner, ok := strings.CutPrefix(address, "["); ok {
+		address, ok = strings.CutSuffix(inner, "]")
+		if !ok {
+			return "", false
+		}
+	}
+
+	return address, net.ParseIP(address) != nil
+}
@@ -0,0 +1,50 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSSHTarget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{input: "root@admin-1", want: "root@admin-1:22", wantErr: nil},
+		{input: "ceph@10.0.0.5:2222", want: "ceph@10.0.0.5:2222", wantErr: nil},
+		{input: "ceph@[fd00::5]:2222", want: "ceph@[fd00::5]:2222", wantErr: nil},
+		{input: "ceph@fd00::5", want: "ceph@[fd00::5]:22", wantErr: nil},
+		{input: "ceph@[fd00::5]", want: "ceph@[fd00::5]:22", wantErr: nil},
+		{input: "", want: "", wantErr: domain.ErrEmptySSHTarget},
+		{input: "admin-1", want: "", wantErr: domain.ErrInvalidSSHTarget},
+		{input: "root@admin-1:0", want: "", wantErr: domain.ErrInvalidSSHTarget},
+		{input: "root@admin-1:22:extra", want: "", wantErr: domain.ErrInvalidSSHTarget},
+		{input: "root@[::1", want: "", wantErr: domain.ErrInvalidSSHTarget},
+		{input: "root@[admin-1]", want: "", wantErr: domain.ErrInvalidSSHTarget},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			target, err := domain.ParseSSHTarget(test.input)
+
+			// Assert
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				require.Nil(t, target)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.want, target.String())
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package appconfig loads the cephdoctor configuration file: global defaults and per-cluster
+// overrides for the container image, timeouts and backend.
+package appconfig
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+var ErrInvalidConfig = errors.New("invalid config")
+
+// Config is the layout of config.yaml. Images maps ceph versions or version prefixes such as
+// "17" or "18.2" to the container image used for clusters running them. Repository selects the
+// cluster repository (file or sqlite) when neither the flag nor the environment does.
+type Config struct {
+	Repository string                   `yaml:"repository"`
+	Defaults   ClusterConfig            `yaml:"defaults"`
+	Clusters   map[string]ClusterConfig `yaml:"clusters"`
+	Images     map[string]string        `yaml:"images"`
+}
+
+// ClusterConfig holds settings for one cluster. Zero fields are left to the next level:
+// per-cluster values fall back to defaults, and defaults fall back to built-in values.
+type ClusterConfig struct {
+	Image          string        `yaml:"image"`
+	CommandTimeout time.Duration `yaml:"commandTimeout"`
+	CleanupTimeout time.Duration `yaml:"cleanupTimeout"`
+	Backend        string        `yaml:"backend"`
+}
+
+// For returns the settings for the named cluster with its overrides applied over the defaults.
+func (c *Config) For(name string) ClusterConfig {
+	merged := c.Defaults
+	override := c.Clusters[name]
+
+	if override.Image != "" {
+		merged.Image = override.Image
+	}
+
+	if override.CommandTimeout != 0 {
+		merged.CommandTimeout = override.CommandTimeout
+	}
+
+	if override.CleanupTimeout != 0 {
+		merged.CleanupTimeout = override.CleanupTimeout
+	}
+
+	if override.Backend != "" {
+		merged.Backend = override.Backend
+	}
+
+	return merged
+}
+
+func (c *Config) validate() error {
+	switch c.Repository {
+	case "", "file", "sqlite":
+	default:
+		return fmt.Errorf("%w: repository: unknown repository %q", ErrInvalidConfig, c.Repository)
+	}
+
+	err := c.Defaults.validate("defaults")
+	if err != nil {
+		return err
+	}
+
+	for name, cluster := range c.Clusters {
+		err = cluster.validate("clusters." + name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.validateImages()
+}
+
+func (c ClusterConfig) validate(section string) error {
+	if c.CommandTimeout < 0 || c.CleanupTimeout < 0 {
+		return fmt.Errorf("%w: %s: timeouts must not be negative", ErrInvalidConfig, section)
+	}
+
+	_, err := domain.ParseBackend(c.Backend)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidConfig, section, err)
+	}
+
+	return nil
+}
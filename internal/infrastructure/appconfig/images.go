@@ -0,0 +1,36 @@
+package appconfig
+
+import (
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// ImageFor returns the image configured for the named cluster running version, which may be ""
+// when the release is not known yet: the per-cluster image, then the longest matching images
+// entry. An empty result leaves the choice to the container backend, which tries its own images
+// per release before falling back to defaults.image.
+func (c *Config) ImageFor(name, version string) string {
+	if image := c.Clusters[name].Image; image != "" {
+		return image
+	}
+
+	image, _ := domain.LookupByCephVersion(c.Images, version)
+
+	return image
+}
+
+func (c *Config) validateImages() error {
+	for version, image := range c.Images {
+		_, err := domain.ParseCephVersion(version)
+		if err != nil {
+			return fmt.Errorf("%w: images: %w", ErrInvalidConfig, err)
+		}
+
+		if image == "" {
+			return fmt.Errorf("%w: images: %s has no image", ErrInvalidConfig, version)
+		}
+	}
+
+	return nil
+}
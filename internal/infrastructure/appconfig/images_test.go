@@ -0,0 +1,43 @@
+package appconfig_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/appconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_ImageFor(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, `
+defaults:
+  image: registry.local/ceph/ceph:v18.2.7
+clusters:
+  pinned:
+    image: registry.local/ceph/ceph:v19.2.3
+images:
+  "17": registry.local/ceph/ceph:v17
+  "17.2.8": registry.local/ceph/ceph:v17.2.8
+`)
+
+	config, err := appconfig.Load(path)
+	require.NoError(t, err)
+
+	// Act
+	pinned := config.ImageFor("pinned", "17.2.8")
+	exact := config.ImageFor("alpha", "17.2.8")
+	major := config.ImageFor("alpha", "17.2.6")
+	fallback := config.ImageFor("alpha", "16.2.15")
+	unknown := config.ImageFor("alpha", "")
+
+	// Assert
+	require.Equal(t, "registry.local/ceph/ceph:v19.2.3", pinned)
+	require.Equal(t, "registry.local/ceph/ceph:v17.2.8", exact)
+	require.Equal(t, "registry.local/ceph/ceph:v17", major)
+	require.Empty(t, fallback)
+	require.Empty(t, unknown)
+}
@@ -0,0 +1,75 @@
+package appconfig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	appDirName     = "ceph-doctor"
+	configFileName = "config.yaml"
+)
+
+var errHomeNotSet = errors.New("HOME is not set")
+
+// DefaultPath returns $XDG_CONFIG_HOME/ceph-doctor/config.yaml, falling back to ~/.config.
+func DefaultPath() (string, error) {
+	if xdgConfigHome, ok := os.LookupEnv("XDG_CONFIG_HOME"); ok && strings.TrimSpace(xdgConfigHome) != "" {
+		return filepath.Join(xdgConfigHome, appDirName, configFileName), nil
+	}
+
+	home, ok := os.LookupEnv("HOME")
+	if !ok || strings.TrimSpace(home) == "" {
+		return "", errHomeNotSet
+	}
+
+	return filepath.Join(home, ".config", appDirName, configFileName), nil
+}
+
+// Load reads the config file at path. An empty path selects DefaultPath, which may be missing;
+// an explicitly given file must exist. Unknown fields are rejected so typos are not ignored.
+func Load(path string) (*Config, error) {
+	explicit := path != ""
+	if !explicit {
+		defaultPath, err := DefaultPath()
+		if err != nil {
+			return nil, fmt.Errorf("resolve default config path: %w", err)
+		}
+
+		path = defaultPath
+	}
+
+	//nolint:gosec // The config path is chosen by the operator.
+	payload, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return &Config{Repository: "", Defaults: ClusterConfig{}, Clusters: nil, Images: nil}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	var config Config
+
+	decoder := yaml.NewDecoder(bytes.NewReader(payload))
+	decoder.KnownFields(true)
+
+	err = decoder.Decode(&config)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidConfig, path, err)
+	}
+
+	err = config.validate()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,121 @@
+package appconfig_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/appconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_MissingDefaultFileUsesBuiltIns(t *testing.T) {
+	// Arrange
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	// Act
+	config, err := appconfig.Load("")
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, appconfig.ClusterConfig{}, config.For("alpha"))
+}
+
+func TestLoad_ReadsDefaultPath(t *testing.T) {
+	// Arrange
+	xdgConfigHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdgConfigHome)
+	writeConfig(t, filepath.Join(xdgConfigHome, "ceph-doctor", "config.yaml"), "defaults:\n  image: mirror/ceph:v18\n")
+
+	// Act
+	config, err := appconfig.Load("")
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "mirror/ceph:v18", config.For("alpha").Image)
+}
+
+func TestLoad_MergesClusterOverrides(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, `
+repository: sqlite
+defaults:
+  image: registry.local/ceph/ceph:v18.2.7
+  commandTimeout: 45s
+  cleanupTimeout: 20s
+clusters:
+  quincy:
+    image: registry.local/ceph/ceph:v17.2.7
+    commandTimeout: 2m
+    backend: ssh
+`)
+
+	// Act
+	config, err := appconfig.Load(path)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "sqlite", config.Repository)
+	require.Equal(t, appconfig.ClusterConfig{
+		Image:          "registry.local/ceph/ceph:v17.2.7",
+		CommandTimeout: 2 * time.Minute,
+		CleanupTimeout: 20 * time.Second,
+		Backend:        "ssh",
+	}, config.For("quincy"))
+	require.Equal(t, appconfig.ClusterConfig{
+		Image:          "registry.local/ceph/ceph:v18.2.7",
+		CommandTimeout: 45 * time.Second,
+		CleanupTimeout: 20 * time.Second,
+		Backend:        "",
+	}, config.For("reef"))
+}
+
+func TestLoad_RejectsInvalidFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"unknown field":    "defaults:\n  imgae: ceph\n",
+		"unknown backend":  "clusters:\n  alpha:\n    backend: docker\n",
+		"unknown repo":     "repository: postgres\n",
+		"negative timeout": "defaults:\n  commandTimeout: -1s\n",
+		"bad duration":     "defaults:\n  cleanupTimeout: soon\n",
+		"bad version":      "images:\n  v18: ceph\n",
+		"empty image":      "images:\n  \"18\": \"\"\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			writeConfig(t, path, content)
+
+			config, err := appconfig.Load(path)
+
+			require.ErrorIs(t, err, appconfig.ErrInvalidConfig)
+			require.Nil(t, config)
+		})
+	}
+}
+
+func TestLoad_ExplicitPathMustExist(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	config, err := appconfig.Load(filepath.Join(t.TempDir(), "missing.yaml"))
+
+	// Assert
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.Nil(t, config)
+}
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
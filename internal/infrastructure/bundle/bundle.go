@@ -0,0 +1,69 @@
+// Package bundle packs registered clusters into a portable tar.gz archive.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+const (
+	manifestName    = "bundle.json"
+	manifestMode    = 0o600
+	maxManifestSize = 16 << 20
+)
+
+var (
+	ErrManifestNotFound   = errors.New("bundle.json not found in archive")
+	ErrUnsupportedVersion = errors.New("unsupported bundle version")
+	ErrPassphraseRequired = errors.New("bundle keys are encrypted; a passphrase is required")
+)
+
+// Write archives clusters to writer. Keys are sealed when passphrase is not empty.
+func Write(writer io.Writer, clusters []*domain.Cluster, passphrase []byte) error {
+	manifest, err := newManifest(clusters, passphrase)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	err = tarWriter.WriteHeader(&tar.Header{ //nolint:exhaustruct // Remaining tar header fields keep their zero values.
+		Name:    manifestName,
+		Mode:    manifestMode,
+		Size:    int64(len(payload)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("write tar header: %w", err)
+	}
+
+	_, err = tarWriter.Write(payload)
+	if err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return fmt.Errorf("close tar: %w", err)
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return fmt.Errorf("close gzip: %w", err)
+	}
+
+	return nil
+}
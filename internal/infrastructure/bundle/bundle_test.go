@@ -0,0 +1,66 @@
+package bundle_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/bundle"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	clusters := newBundleClusters(t)
+
+	var archive bytes.Buffer
+
+	require.NoError(t, bundle.Write(&archive, clusters, nil))
+
+	// Act
+	restored, err := bundle.Read(&archive, nil)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, clusters, restored)
+}
+
+func TestBundle_EncryptedRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	clusters := newBundleClusters(t)
+
+	var archive bytes.Buffer
+
+	require.NoError(t, bundle.Write(&archive, clusters, []byte("passphrase")))
+	encrypted := archive.Bytes()
+
+	// Act
+	_, missingErr := bundle.Read(bytes.NewReader(encrypted), nil)
+	restored, err := bundle.Read(bytes.NewReader(encrypted), []byte("passphrase"))
+
+	// Assert
+	require.ErrorIs(t, missingErr, bundle.ErrPassphraseRequired)
+	require.NoError(t, err)
+	require.Equal(t, clusters, restored)
+}
+
+func newBundleClusters(t *testing.T) []*domain.Cluster {
+	t.Helper()
+
+	target, err := domain.ParseSSHTarget("ceph@admin.example:2222")
+	require.NoError(t, err)
+
+	alpha, err := domain.NewCluster("alpha", "secret-a", []string{"10.0.0.1", "10.0.0.2:6789"},
+		domain.WithBackend(domain.BackendSSH), domain.WithSSHTarget(target),
+		domain.WithLabels(map[string]string{"env": "prod", "dc": "seoul"}))
+	require.NoError(t, err)
+
+	beta, err := domain.NewCluster("beta", "secret-b", []string{"10.1.0.1"})
+	require.NoError(t, err)
+
+	return []*domain.Cluster{alpha, beta}
+}
@@ -0,0 +1,94 @@
+package bundle
+
+import (
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/secretbox"
+)
+
+const schemaVersion = "cephdoctor-bundle/v1"
+
+type manifest struct {
+	SchemaVersion string          `json:"schemaVersion"`
+	Salt          []byte          `json:"salt,omitempty"`
+	Clusters      []clusterRecord `json:"clusters"`
+}
+
+// clusterRecord carries either the plain key or, in an encrypted bundle, the sealed key.
+type clusterRecord struct {
+	Name      string   `json:"name"`
+	Key       string   `json:"key,omitempty"`
+	SealedKey string   `json:"sealedKey,omitempty"`
+	Hosts     []string `json:"hosts"`
+	Entity    string   `json:"entity,omitempty"`
+	Backend   string   `json:"backend,omitempty"`
+	SSH       string   `json:"ssh,omitempty"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func newManifest(clusters []*domain.Cluster, passphrase []byte) (manifest, error) {
+	result := manifest{SchemaVersion: schemaVersion, Salt: nil, Clusters: make([]clusterRecord, 0, len(clusters))}
+
+	var box *secretbox.Box
+
+	if len(passphrase) > 0 {
+		salt, err := secretbox.NewSalt()
+		if err != nil {
+			return manifest{}, fmt.Errorf("new salt: %w", err)
+		}
+
+		box, err = secretbox.New(passphrase, salt)
+		if err != nil {
+			return manifest{}, fmt.Errorf("new secret box: %w", err)
+		}
+
+		result.Salt = salt
+	}
+
+	for _, cluster := range clusters {
+		record, err := newClusterRecord(cluster, box)
+		if err != nil {
+			return manifest{}, err
+		}
+
+		result.Clusters = append(result.Clusters, record)
+	}
+
+	return result, nil
+}
+
+func (m manifest) clusters(passphrase []byte) ([]*domain.Cluster, error) {
+	if m.SchemaVersion != schemaVersion {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedVersion, m.SchemaVersion)
+	}
+
+	var box *secretbox.Box
+
+	if len(m.Salt) > 0 {
+		if len(passphrase) == 0 {
+			return nil, ErrPassphraseRequired
+		}
+
+		var err error
+
+		box, err = secretbox.New(passphrase, m.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("new secret box: %w", err)
+		}
+	}
+
+	clusters := make([]*domain.Cluster, 0, len(m.Clusters))
+
+	for _, record := range m.Clusters {
+		cluster, err := record.toCluster(box)
+		if err != nil {
+			return nil, fmt.Errorf("restore cluster %q: %w", record.Name, err)
+		}
+
+		clusters = append(clusters, cluster)
+	}
+
+	return clusters, nil
+}
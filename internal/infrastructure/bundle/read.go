@@ -0,0 +1,46 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// Read restores the clusters archived by Write.
+func Read(reader io.Reader, passphrase []byte) ([]*domain.Cluster, error) {
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("open gzip: %w", err)
+	}
+
+	tarReader := tar.NewReader(gzipReader)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrManifestNotFound
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read tar: %w", err)
+		}
+
+		if header.Name != manifestName {
+			continue
+		}
+
+		var manifest manifest
+
+		err = json.NewDecoder(io.LimitReader(tarReader, maxManifestSize)).Decode(&manifest)
+		if err != nil {
+			return nil, fmt.Errorf("decode manifest: %w", err)
+		}
+
+		return manifest.clusters(passphrase)
+	}
+}
@@ -0,0 +1,66 @@
+package bundle
+
+import (
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/secretbox"
+)
+
+func newClusterRecord(cluster *domain.Cluster, box *secretbox.Box) (clusterRecord, error) {
+	record := clusterRecord{
+		Name:      cluster.Name(),
+		Key:       cluster.Key(),
+		SealedKey: "",
+		Hosts:     cluster.Hosts(),
+		Entity:    cluster.Entity(),
+		Backend:   string(cluster.Backend()),
+		SSH:       "",
+		Labels:    cluster.Labels(),
+	}
+
+	if target := cluster.SSHTarget(); target != nil {
+		record.SSH = target.String()
+	}
+
+	if box != nil {
+		sealed, err := box.Seal(record.Key)
+		if err != nil {
+			return clusterRecord{}, fmt.Errorf("seal key of %q: %w", record.Name, err)
+		}
+
+		record.Key, record.SealedKey = "", sealed
+	}
+
+	return record, nil
+}
+
+func (r clusterRecord) toCluster(box *secretbox.Box) (*domain.Cluster, error) {
+	key := r.Key
+
+	if box != nil {
+		opened, err := box.Open(r.SealedKey)
+		if err != nil {
+			return nil, fmt.Errorf("open key: %w", err)
+		}
+
+		key = opened
+	}
+
+	opts := []domain.ClusterOption{domain.WithBackend(domain.Backend(r.Backend)), domain.WithLabels(r.Labels)}
+
+	if r.Entity != "" {
+		opts = append(opts, domain.WithEntity(r.Entity))
+	}
+
+	if r.SSH != "" {
+		target, err := domain.ParseSSHTarget(r.SSH)
+		if err != nil {
+			return nil, fmt.Errorf("parse ssh target: %w", err)
+		}
+
+		opts = append(opts, domain.WithSSHTarget(target))
+	}
+
+	return domain.NewCluster(r.Name, key, r.Hosts, opts...) //nolint:wrapcheck // Caller wraps validation errors.
+}
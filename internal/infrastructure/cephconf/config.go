@@ -0,0 +1,82 @@
+// Package cephconf renders the ceph.conf and keyring files used to reach a cluster.
+package cephconf
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+const (
+	ConfigFile = "ceph.conf"
+	filePerm   = 0o600
+)
+
+// BuildConfig renders a minimal ceph.conf pointing at the cluster monitors.
+func BuildConfig(cluster *domain.Cluster) string {
+	return fmt.Sprintf(
+		"[global]\n        mon_host = %s\n",
+		strings.Join(cluster.Hosts(), " "),
+	)
+}
+
+// KeyringFile names the keyring of entity the way ceph looks it up, as ceph.<entity>.keyring.
+func KeyringFile(entity string) string {
+	return keyringFileName("ceph", entity)
+}
+
+func keyringFileName(cluster, entity string) string {
+	return cluster + "." + entity + ".keyring"
+}
+
+// BuildKeyring renders a keyring holding the cluster key for its entity.
+// Caps are enforced by the monitors, so the client-side keyring carries none.
+func BuildKeyring(cluster *domain.Cluster) string {
+	return fmt.Sprintf("[%s]\n        key = %s\n", cluster.Entity(), cluster.Key())
+}
+
+// Args returns the ceph flags that authenticate as the cluster entity with the files in dir.
+func Args(dir string, cluster *domain.Cluster) []string {
+	return []string{
+		"--conf", path.Join(dir, ConfigFile),
+		"--keyring", path.Join(dir, KeyringFile(cluster.Entity())),
+		"--name", cluster.Entity(),
+	}
+}
+
+// WriteDir writes ConfigFile and KeyringFile for the cluster into dir.
+func WriteDir(dir string, cluster *domain.Cluster) error {
+	err := os.WriteFile(filepath.Join(dir, ConfigFile), []byte(BuildConfig(cluster)), filePerm)
+	if err != nil {
+		return fmt.Errorf("write ceph.conf: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, KeyringFile(cluster.Entity())), []byte(BuildKeyring(cluster)), filePerm)
+	if err != nil {
+		return fmt.Errorf("write keyring: %w", err)
+	}
+
+	return nil
+}
+
+// WriteTempDir writes the cluster configuration into a fresh directory created below root.
+// An empty root uses the default temporary directory.
+func WriteTempDir(root, pattern string, cluster *domain.Cluster) (string, error) {
+	dir, err := os.MkdirTemp(root, pattern)
+	if err != nil {
+		return "", fmt.Errorf("create temp config dir: %w", err)
+	}
+
+	err = WriteDir(dir, cluster)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+
+		return "", err
+	}
+
+	return dir, nil
+}
@@ -0,0 +1,94 @@
+package cephconf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+const confSuffix = ".conf"
+
+var (
+	ErrMonHostNotFound = errors.New("mon_host not found in ceph.conf")
+	ErrKeyNotFound     = errors.New("key not found in keyring")
+	ErrAmbiguousKey    = errors.New("keyring holds several entries and none is client.admin")
+)
+
+// Source locates the files of one cluster on disk.
+// An empty Entity takes client.admin from the keyring, or its only other entry.
+type Source struct {
+	Name        string
+	Entity      string
+	ConfPath    string
+	KeyringPath string
+}
+
+// NewSource names the cluster after the conf file, as ceph does for /etc/ceph/<cluster>.conf,
+// and defaults the keyring to <cluster>.<entity>.keyring next to it.
+func NewSource(name, entity, confPath, keyringPath string) Source {
+	cluster := strings.TrimSuffix(filepath.Base(confPath), confSuffix)
+	if name == "" {
+		name = cluster
+	}
+
+	if keyringPath == "" {
+		keyringEntity := entity
+		if keyringEntity == "" {
+			keyringEntity = domain.DefaultEntity
+		}
+
+		keyringPath = filepath.Join(filepath.Dir(confPath), keyringFileName(cluster, keyringEntity))
+	}
+
+	return Source{Name: name, Entity: entity, ConfPath: confPath, KeyringPath: keyringPath}
+}
+
+// FindSources returns a Source for every *.conf file in dir, sorted by file name.
+// The keyring of each is looked up for entity as in NewSource.
+func FindSources(dir, entity string) ([]Source, error) {
+	confPaths, err := filepath.Glob(filepath.Join(dir, "*"+confSuffix))
+	if err != nil {
+		return nil, fmt.Errorf("glob conf files: %w", err)
+	}
+
+	sources := make([]Source, 0, len(confPaths))
+	for _, confPath := range confPaths {
+		sources = append(sources, NewSource("", entity, confPath, ""))
+	}
+
+	return sources, nil
+}
+
+// Load reads the conf and keyring files of source into a cluster.
+func Load(source Source) (*domain.Cluster, error) {
+	conf, err := os.ReadFile(source.ConfPath)
+	if err != nil {
+		return nil, fmt.Errorf("read conf: %w", err)
+	}
+
+	keyring, err := os.ReadFile(source.KeyringPath)
+	if err != nil {
+		return nil, fmt.Errorf("read keyring: %w", err)
+	}
+
+	hosts, err := ParseConfig(string(conf))
+	if err != nil {
+		return nil, err
+	}
+
+	entity, key, err := ParseKeyring(string(keyring), source.Entity)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := domain.NewCluster(source.Name, key, hosts, domain.WithEntity(entity))
+	if err != nil {
+		return nil, fmt.Errorf("new cluster: %w", err)
+	}
+
+	return cluster, nil
+}
@@ -0,0 +1,187 @@
+package cephconf_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephconf"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMonHost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{name: "plain", value: "10.0.0.1, 10.0.0.2:6789", want: []string{"10.0.0.1", "10.0.0.2:6789"}},
+		{
+			name:  "address vectors",
+			value: "[v2:10.0.0.1:3300/0,v1:10.0.0.1:6789/0] [v1:10.0.0.2:6789]",
+			want:  []string{"10.0.0.1:3300", "10.0.0.2:6789"},
+		},
+		{name: "prefixed", value: "v2:10.0.0.1:3300,v1:10.0.0.2:6789", want: []string{"10.0.0.1:3300", "10.0.0.2:6789"}},
+		{name: "ipv6", value: "[v2:[fd00::1]:3300,v1:[fd00::1]:6789]", want: []string{"[fd00::1]:3300"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			hosts, err := cephconf.ParseMonHost(test.value)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, test.want, hosts)
+		})
+	}
+}
+
+func TestParseMonHost_UnterminatedVector(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	_, err := cephconf.ParseMonHost("[v2:10.0.0.1:3300,v1:10.0.0.1:6789")
+
+	// Assert
+	require.ErrorIs(t, err, cephconf.ErrInvalidMonHost)
+}
+
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	content := "# generated by cephadm\n[global]\n\tfsid = 1b2c\n\tmon host = [v2:10.0.0.1:3300,v1:10.0.0.1:6789] ; mons\n"
+
+	// Act
+	hosts, err := cephconf.ParseConfig(content)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.1:3300"}, hosts)
+}
+
+func TestParseConfig_MissingMonHost(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	_, err := cephconf.ParseConfig("[client]\nmon_host = 10.0.0.1\n")
+
+	// Assert
+	require.ErrorIs(t, err, cephconf.ErrMonHostNotFound)
+}
+
+func TestParseKeyring(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	content := "[client.bootstrap]\n\tkey = Ym9vdA==\n[client.admin]\n\tkey = YWRtaW4=\n\tcaps mon = \"allow *\"\n"
+
+	// Act
+	adminEntity, adminKey, adminErr := cephconf.ParseKeyring(content, "")
+	entity, key, err := cephconf.ParseKeyring(content, "client.bootstrap")
+
+	// Assert
+	require.NoError(t, adminErr)
+	require.Equal(t, "client.admin", adminEntity)
+	require.Equal(t, "YWRtaW4=", adminKey)
+	require.NoError(t, err)
+	require.Equal(t, "client.bootstrap", entity)
+	require.Equal(t, "Ym9vdA==", key)
+}
+
+func TestParseKeyring_FallsBackToOnlyEntry(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	entity, key, err := cephconf.ParseKeyring("[client.cephdoctor]\n\tkey = ZG9j\n", "")
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "client.cephdoctor", entity)
+	require.Equal(t, "ZG9j", key)
+}
+
+func TestParseKeyring_RefusesToGuessAmongSeveralEntries(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	_, _, err := cephconf.ParseKeyring("[client.cephdoctor]\n\tkey = ZG9j\n[client.backup]\n\tkey = YmFj\n", "")
+
+	// Assert
+	require.ErrorIs(t, err, cephconf.ErrAmbiguousKey)
+	require.ErrorContains(t, err, "client.cephdoctor, client.backup")
+}
+
+func TestParseKeyring_MissingKey(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	_, _, err := cephconf.ParseKeyring("[client.admin]\n\tkey = YWRtaW4=\n", "client.cephdoctor")
+
+	// Assert
+	require.ErrorIs(t, err, cephconf.ErrKeyNotFound)
+}
+
+func TestFindSourcesAndLoad(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	dir := t.TempDir()
+	writeFile(t, dir, "prod.conf", "[global]\nmon_host = 10.0.0.1,10.0.0.2\n")
+	writeFile(t, dir, "prod.client.admin.keyring", "[client.admin]\nkey = cHJvZA==\n")
+	writeFile(t, dir, "rbdmap", "")
+
+	// Act
+	sources, err := cephconf.FindSources(dir, "")
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+
+	cluster, err := cephconf.Load(sources[0])
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "prod", cluster.Name())
+	require.Equal(t, "cHJvZA==", cluster.Key())
+	require.Equal(t, []string{"10.0.0.1:3300", "10.0.0.2:3300"}, cluster.Hosts())
+}
+
+func TestNewSource_OverridesNameAndKeyring(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	source := cephconf.NewSource("alpha", "client.cephdoctor", "/etc/ceph/ceph.conf", "/tmp/doctor.keyring")
+	defaulted := cephconf.NewSource("", "client.cephdoctor", "/etc/ceph/prod.conf", "")
+
+	// Assert
+	require.Equal(t, cephconf.Source{
+		Name:        "alpha",
+		Entity:      "client.cephdoctor",
+		ConfPath:    "/etc/ceph/ceph.conf",
+		KeyringPath: "/tmp/doctor.keyring",
+	}, source)
+	require.Equal(t, "prod", defaulted.Name)
+	require.Equal(t, "/etc/ceph/prod.client.cephdoctor.keyring", defaulted.KeyringPath)
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
+
+func TestProvisionCommand(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	command := cephconf.ProvisionCommand("client.cephdoctor")
+
+	// Assert
+	require.Equal(t,
+		"ceph auth get-or-create client.cephdoctor mon 'allow r' mgr 'allow r' -o ceph.client.cephdoctor.keyring",
+		command)
+}
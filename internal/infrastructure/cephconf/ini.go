@@ -0,0 +1,58 @@
+package cephconf
+
+import (
+	"strings"
+)
+
+// section is one [name] block of a ceph INI file with option names normalized.
+type section struct {
+	name    string
+	options map[string]string
+}
+
+// parseINI reads ceph-style INI content. Option names are compared the way ceph does,
+// treating spaces, dashes and underscores alike, and comments start with '#' or ';'.
+func parseINI(content string) []section {
+	var sections []section
+
+	for line := range strings.Lines(content) {
+		line = strings.TrimSpace(stripComment(line))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			sections = append(sections, section{name: name, options: map[string]string{}})
+
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || len(sections) == 0 {
+			continue
+		}
+
+		current := sections[len(sections)-1]
+		current.options[normalizeOption(key)] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+
+	return sections
+}
+
+func stripComment(line string) string {
+	index := strings.IndexAny(line, "#;")
+	if index < 0 {
+		return line
+	}
+
+	return line[:index]
+}
+
+func normalizeOption(key string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+
+	return strings.Join(strings.FieldsFunc(key, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	}), "_")
+}
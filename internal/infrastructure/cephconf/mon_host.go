@@ -0,0 +1,95 @@
+package cephconf
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidMonHost = errors.New("invalid mon_host entry")
+
+// ParseMonHost converts a mon_host value into host[:port] entries, one per monitor.
+// Address vectors such as [v2:10.0.0.1:3300,v1:10.0.0.1:6789] yield their v2 address.
+func ParseMonHost(value string) ([]string, error) {
+	var hosts []string
+
+	for _, token := range splitMonHost(value) {
+		host, err := parseMonAddress(token)
+		if err != nil {
+			return nil, err
+		}
+
+		hosts = append(hosts, host)
+	}
+
+	return hosts, nil
+}
+
+// splitMonHost splits on commas, semicolons and whitespace outside of brackets.
+// An unterminated bracket keeps the rest of the value as one token.
+func splitMonHost(value string) []string {
+	var (
+		tokens []string
+		depth  int
+		start  int
+	)
+
+	for index, r := range value + " " {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case depth == 0 && strings.ContainsRune(", ;\t", r):
+			if token := value[start:index]; token != "" {
+				tokens = append(tokens, token)
+			}
+
+			start = index + 1
+		}
+	}
+
+	if start < len(value) {
+		tokens = append(tokens, value[start:])
+	}
+
+	return tokens
+}
+
+func parseMonAddress(token string) (string, error) {
+	inner, isVector := strings.CutPrefix(token, "[")
+	if !isVector || !strings.Contains(inner, "v1:") && !strings.Contains(inner, "v2:") {
+		return stripAddress(token), nil
+	}
+
+	inner, ok := strings.CutSuffix(inner, "]")
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrInvalidMonHost, token)
+	}
+
+	var fallback string
+
+	for address := range strings.SplitSeq(inner, ",") {
+		if v2, ok := strings.CutPrefix(address, "v2:"); ok {
+			return stripAddress(v2), nil
+		}
+
+		if fallback == "" {
+			fallback = address
+		}
+	}
+
+	if fallback == "" {
+		return "", fmt.Errorf("%w: %s", ErrInvalidMonHost, token)
+	}
+
+	return stripAddress(fallback), nil
+}
+
+// stripAddress drops the protocol prefix and the /nonce suffix of a single address.
+func stripAddress(address string) string {
+	address = strings.TrimPrefix(strings.TrimPrefix(address, "v1:"), "v2:")
+	address, _, _ = strings.Cut(address, "/")
+
+	return address
+}
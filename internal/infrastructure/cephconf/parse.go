@@ -0,0 +1,61 @@
+package cephconf
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// ParseConfig returns the monitor hosts listed by mon_host in the [global] section.
+func ParseConfig(content string) ([]string, error) {
+	for _, section := range parseINI(content) {
+		if section.name != "global" {
+			continue
+		}
+
+		value, ok := section.options["mon_host"]
+		if !ok || value == "" {
+			continue
+		}
+
+		hosts, err := ParseMonHost(value)
+		if err != nil {
+			return nil, fmt.Errorf("parse mon_host: %w", err)
+		}
+
+		return hosts, nil
+	}
+
+	return nil, ErrMonHostNotFound
+}
+
+// ParseKeyring returns the entity and key of the keyring entry for entity.
+// An empty entity picks client.admin, or the only entry when there is no admin entry.
+func ParseKeyring(content, entity string) (string, string, error) {
+	var entities, keys []string
+
+	for _, section := range parseINI(content) {
+		key := section.options["key"]
+		if key == "" {
+			continue
+		}
+
+		if section.name == entity || entity == "" && section.name == domain.DefaultEntity {
+			return section.name, key, nil
+		}
+
+		entities, keys = append(entities, section.name), append(keys, key)
+	}
+
+	if entity != "" || len(keys) == 0 {
+		return "", "", fmt.Errorf("%w for %s", ErrKeyNotFound, cmp.Or(entity, "any entity"))
+	}
+
+	if len(keys) > 1 {
+		return "", "", fmt.Errorf("%w: %s", ErrAmbiguousKey, strings.Join(entities, ", "))
+	}
+
+	return entities[0], keys[0], nil
+}
@@ -0,0 +1,17 @@
+package cephconf
+
+import "strings"
+
+// readOnlyCaps grant what cephdoctor reads: cluster maps from the monitors and stats from the manager.
+func readOnlyCaps() []string {
+	return []string{"mon", "'allow r'", "mgr", "'allow r'"}
+}
+
+// ProvisionCommand returns the ceph command that creates entity with read-only caps
+// and saves its keyring under the name ceph looks up for it.
+func ProvisionCommand(entity string) string {
+	args := append([]string{"ceph", "auth", "get-or-create", entity}, readOnlyCaps()...)
+	args = append(args, "-o", KeyringFile(entity))
+
+	return strings.Join(args, " ")
+}
@@ -0,0 +1,47 @@
+package cephjson
+
+import (
+	"sort"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+func toHealth(document healthDocument) domain.Health {
+	checks := make([]domain.HealthCheck, 0, len(document.Checks))
+	for code, check := range document.Checks {
+		checks = append(checks, domain.HealthCheck{
+			Code:     code,
+			Severity: domain.HealthStatus(check.Severity),
+			Message:  check.Summary.Message,
+			Count:    check.Summary.Count,
+			Muted:    check.Muted,
+		})
+	}
+
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].Code < checks[j].Code
+	})
+
+	return domain.Health{
+		Status: domain.HealthStatus(document.Status),
+		Checks: checks,
+	}
+}
+
+func toPGMap(document pgMapDocument) domain.PGMap {
+	states := make([]domain.PGStateCount, 0, len(document.PGsByState))
+	for _, state := range document.PGsByState {
+		states = append(states, domain.PGStateCount{State: state.StateName, Count: state.Count})
+	}
+
+	return domain.PGMap{
+		NumPGs:           document.NumPGs,
+		NumPools:         document.NumPools,
+		NumObjects:       document.NumObjects,
+		States:           states,
+		DegradedObjects:  document.DegradedObjects,
+		DegradedRatio:    document.DegradedRatio,
+		MisplacedObjects: document.MisplacedObjects,
+		MisplacedRatio:   document.MisplacedRatio,
+	}
+}
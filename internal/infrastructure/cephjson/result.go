@@ -0,0 +1,20 @@
+package cephjson
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+var ErrStatusExit = errors.New("ceph status returned non-zero exit status")
+
+// StatusResult turns the outcome of `ceph status --format json` into a status.
+// A non-zero exit code yields the raw streams together with ErrStatusExit.
+func StatusResult(stdout, stderr string, exitCode int) (*domain.CephStatus, error) {
+	if exitCode != 0 {
+		return rawStatus(stdout, stderr), fmt.Errorf("%w: %d", ErrStatusExit, exitCode)
+	}
+
+	return ParseStatus(stdout, stderr)
+}
@@ -0,0 +1,66 @@
+// Package cephjson decodes JSON output of ceph CLI commands into domain types.
+package cephjson
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// ParseStatus decodes `ceph status --format json` output.
+// The returned status always carries the raw streams, even when decoding fails.
+func ParseStatus(stdout, stderr string) (*domain.CephStatus, error) {
+	var document statusDocument
+
+	err := json.Unmarshal([]byte(stdout), &document)
+	if err != nil {
+		return rawStatus(stdout, stderr), fmt.Errorf("decode ceph status: %w", err)
+	}
+
+	numMons := document.MonMap.NumMons
+	if numMons == 0 {
+		numMons = len(document.MonMap.Mons)
+	}
+
+	return &domain.CephStatus{
+		FSID:    document.FSID,
+		Version: "",
+		Health:  toHealth(document.Health),
+		MonMap: domain.MonMap{
+			Epoch:       document.MonMap.Epoch,
+			NumMons:     numMons,
+			QuorumNames: document.QuorumNames,
+		},
+		MgrMap: domain.MgrMap{
+			Available:   document.MgrMap.Available,
+			NumStandbys: document.MgrMap.NumStandbys,
+			Modules:     document.MgrMap.Modules,
+		},
+		OSDMap: domain.OSDMap(document.OSDMap),
+		PGMap:  toPGMap(document.PGMap),
+		Usage: domain.Usage{
+			DataBytes:  document.PGMap.DataBytes,
+			UsedBytes:  document.PGMap.BytesUsed,
+			AvailBytes: document.PGMap.BytesAvail,
+			TotalBytes: document.PGMap.BytesTotal,
+		},
+		IO: domain.IORates{
+			ReadBytesPerSec:  document.PGMap.ReadBytesSec,
+			WriteBytesPerSec: document.PGMap.WriteBytesSec,
+			ReadOpsPerSec:    document.PGMap.ReadOpPerSec,
+			WriteOpsPerSec:   document.PGMap.WriteOpPerSec,
+		},
+		Stdout: stdout,
+		Stderr: stderr,
+	}, nil
+}
+
+func rawStatus(stdout, stderr string) *domain.CephStatus {
+	var status domain.CephStatus
+
+	status.Stdout = stdout
+	status.Stderr = stderr
+
+	return &status
+}
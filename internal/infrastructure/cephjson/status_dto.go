@@ -0,0 +1,79 @@
+package cephjson
+
+//nolint:tagliatelle // Field names follow the ceph status JSON schema.
+type statusDocument struct {
+	FSID        string         `json:"fsid"`
+	Health      healthDocument `json:"health"`
+	QuorumNames []string       `json:"quorum_names"`
+	MonMap      monMapDocument `json:"monmap"`
+	MgrMap      mgrMapDocument `json:"mgrmap"`
+	OSDMap      osdMapDocument `json:"osdmap"`
+	PGMap       pgMapDocument  `json:"pgmap"`
+}
+
+type healthDocument struct {
+	Status string                         `json:"status"`
+	Checks map[string]healthCheckDocument `json:"checks"`
+}
+
+type healthCheckDocument struct {
+	Severity string `json:"severity"`
+	Summary  struct {
+		Message string `json:"message"`
+		Count   int    `json:"count"`
+	} `json:"summary"`
+	Muted bool `json:"muted"`
+}
+
+//nolint:tagliatelle // Field names follow the ceph status JSON schema.
+type monMapDocument struct {
+	Epoch   int               `json:"epoch"`
+	NumMons int               `json:"num_mons"`
+	Mons    []monitorDocument `json:"mons"`
+}
+
+type monitorDocument struct {
+	Name string `json:"name"`
+}
+
+//nolint:tagliatelle // Field names follow the ceph status JSON schema.
+type mgrMapDocument struct {
+	Available   bool     `json:"available"`
+	NumStandbys int      `json:"num_standbys"`
+	Modules     []string `json:"modules"`
+}
+
+//nolint:tagliatelle // Field names follow the ceph status JSON schema.
+type osdMapDocument struct {
+	Epoch          int `json:"epoch"`
+	NumOSDs        int `json:"num_osds"`
+	NumUpOSDs      int `json:"num_up_osds"`
+	NumInOSDs      int `json:"num_in_osds"`
+	NumRemappedPGs int `json:"num_remapped_pgs"`
+}
+
+//nolint:tagliatelle // Field names follow the ceph status JSON schema.
+type pgMapDocument struct {
+	PGsByState       []pgStateDocument `json:"pgs_by_state"`
+	NumPGs           int               `json:"num_pgs"`
+	NumPools         int               `json:"num_pools"`
+	NumObjects       int64             `json:"num_objects"`
+	DataBytes        uint64            `json:"data_bytes"`
+	BytesUsed        uint64            `json:"bytes_used"`
+	BytesAvail       uint64            `json:"bytes_avail"`
+	BytesTotal       uint64            `json:"bytes_total"`
+	ReadBytesSec     int64             `json:"read_bytes_sec"`
+	WriteBytesSec    int64             `json:"write_bytes_sec"`
+	ReadOpPerSec     int64             `json:"read_op_per_sec"`
+	WriteOpPerSec    int64             `json:"write_op_per_sec"`
+	DegradedObjects  int64             `json:"degraded_objects"`
+	DegradedRatio    float64           `json:"degraded_ratio"`
+	MisplacedObjects int64             `json:"misplaced_objects"`
+	MisplacedRatio   float64           `json:"misplaced_ratio"`
+}
+
+//nolint:tagliatelle // Field names follow the ceph status JSON schema.
+type pgStateDocument struct {
+	StateName string `json:"state_name"`
+	Count     int    `json:"count"`
+}
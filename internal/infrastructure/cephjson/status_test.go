@@ -0,0 +1,156 @@
+package cephjson_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephjson"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatus_DecodesStatusDocument(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	payload, err := os.ReadFile(filepath.Join("testdata", "status_warn.json"))
+	require.NoError(t, err)
+
+	// Act
+	status, err := cephjson.ParseStatus(string(payload), "")
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "3c7a2f1e-8d2b-11ee-9f43-525400a1b2c3", status.FSID)
+	require.Equal(t, domain.HealthWarn, status.Health.Status)
+	require.Len(t, status.Health.Checks, 2)
+	require.Equal(t, "OSD_DOWN", status.Health.Checks[0].Code)
+	require.Equal(t, "1 osds down", status.Health.Checks[0].Message)
+	require.Equal(t, "PG_DEGRADED", status.Health.Checks[1].Code)
+	require.Equal(t, []string{"mon-a", "mon-b", "mon-c"}, status.MonMap.QuorumNames)
+	require.Equal(t, 3, status.MonMap.NumMons)
+	require.True(t, status.MgrMap.Available)
+	require.Equal(t, 1, status.MgrMap.NumStandbys)
+	require.Equal(t, 6, status.OSDMap.NumOSDs)
+	require.Equal(t, 5, status.OSDMap.NumUpOSDs)
+	require.Equal(t, 97, status.PGMap.NumPGs)
+	require.Equal(t, []domain.PGStateCount{
+		{State: "active+clean", Count: 93},
+		{State: "active+undersized+degraded", Count: 4},
+	}, status.PGMap.States)
+	require.Equal(t, uint64(63887638528), status.Usage.TotalBytes)
+	require.Equal(t, int64(7), status.IO.WriteOpsPerSec)
+	require.Equal(t, string(payload), status.Stdout)
+}
+
+func TestParseStatus_CountsLegacyMonitorList(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	payload := `{"fsid":"f","monmap":{"epoch":1,"mons":[{"name":"a"},{"name":"b"}]}}`
+
+	// Act
+	status, err := cephjson.ParseStatus(payload, "")
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, 2, status.MonMap.NumMons)
+}
+
+func TestParseStatus_KeepsRawOutputOnDecodeError(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	stdout := "not json"
+	stderr := "auth failed"
+
+	// Act
+	status, err := cephjson.ParseStatus(stdout, stderr)
+
+	// Assert
+	require.ErrorContains(t, err, "decode ceph status")
+	require.Equal(t, stdout, status.Stdout)
+	require.Equal(t, stderr, status.Stderr)
+	require.Empty(t, status.FSID)
+}
+
+func TestStatusResult_NonZeroExitKeepsRawOutput(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	status, err := cephjson.StatusResult("", "[errno 13] RADOS permission denied", 13)
+
+	// Assert
+	require.ErrorIs(t, err, cephjson.ErrStatusExit)
+	require.ErrorContains(t, err, "13")
+	require.Equal(t, "[errno 13] RADOS permission denied", status.Stderr)
+}
+
+func TestParseStatus_DecodesClusterStates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		file        string
+		health      domain.HealthStatus
+		checks      []string
+		quorum      int
+		mgr         bool
+		upInOSDs    [2]int
+		usedBytes   uint64
+		lastPGState string
+	}{
+		{
+			file: "status_healthy.json", health: domain.HealthOK, checks: []string{}, quorum: 3, mgr: true,
+			upInOSDs: [2]int{6, 6}, usedBytes: 17179869184, lastPGState: "active+clean",
+		},
+		{
+			file: "status_degraded.json", health: domain.HealthWarn,
+			checks: []string{"OSD_DOWN", "PG_DEGRADED", "RECENT_CRASH (muted)"}, quorum: 3, mgr: true,
+			upInOSDs: [2]int{5, 6}, usedBytes: 3758096384, lastPGState: "active+undersized+degraded",
+		},
+		{
+			file: "status_outage.json", health: domain.HealthErr,
+			checks: []string{"MON_DOWN", "PG_AVAILABILITY"}, quorum: 1, mgr: false,
+			upInOSDs: [2]int{4, 3}, usedBytes: 92341796864, lastPGState: "peering",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			payload, err := os.ReadFile(filepath.Join("testdata", test.file))
+			require.NoError(t, err)
+
+			// Act
+			status, err := cephjson.ParseStatus(string(payload), "")
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, test.health, status.Health.Status)
+			require.Equal(t, test.checks, describeChecks(status.Health.Checks))
+			require.Len(t, status.MonMap.QuorumNames, test.quorum)
+			require.Equal(t, test.mgr, status.MgrMap.Available)
+			require.Equal(t, test.upInOSDs, [2]int{status.OSDMap.NumUpOSDs, status.OSDMap.NumInOSDs})
+			require.Equal(t, test.usedBytes, status.Usage.UsedBytes)
+			require.Equal(t, test.lastPGState, status.PGMap.States[len(status.PGMap.States)-1].State)
+		})
+	}
+}
+
+func describeChecks(checks []domain.HealthCheck) []string {
+	codes := make([]string, 0, len(checks))
+	for _, check := range checks {
+		if check.Muted {
+			codes = append(codes, check.Code+" (muted)")
+
+			continue
+		}
+
+		codes = append(codes, check.Code)
+	}
+
+	return codes
+}
@@ -0,0 +1,73 @@
+package cephjson
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+var ErrNoMonVersion = errors.New("ceph versions reported no monitor version")
+
+// ParseVersions decodes `ceph versions --format json` output into the release the monitors run,
+// such as 18.2.7. During an upgrade the oldest monitor release is returned, because that is the
+// one a client still has to talk to.
+func ParseVersions(stdout string) (string, error) {
+	var document map[string]map[string]int
+
+	err := json.Unmarshal([]byte(stdout), &document)
+	if err != nil {
+		return "", fmt.Errorf("decode ceph versions: %w", err)
+	}
+
+	versions := make([]string, 0, len(document["mon"]))
+
+	for banner := range document["mon"] {
+		version, err := versionFromBanner(banner)
+		if err != nil {
+			return "", err
+		}
+
+		versions = append(versions, version)
+	}
+
+	if len(versions) == 0 {
+		return "", ErrNoMonVersion
+	}
+
+	return slices.MinFunc(versions, compareVersions), nil
+}
+
+// versionFromBanner extracts 18.2.7 from "ceph version 18.2.7 (<sha1>) reef (stable)".
+// Development builds append "-<commits>-g<sha1>" to the number, which is dropped.
+func versionFromBanner(banner string) (string, error) {
+	fields := strings.Fields(banner)
+	if len(fields) < 3 || fields[0] != "ceph" || fields[1] != "version" {
+		return "", fmt.Errorf("%w: %q", domain.ErrInvalidCephVersion, banner)
+	}
+
+	number, _, _ := strings.Cut(fields[2], "-")
+
+	return domain.ParseCephVersion(number) //nolint:wrapcheck // The domain error already names the value.
+}
+
+// compareVersions orders validated dotted versions numerically, so 9.2.1 sorts before 10.2.0.
+func compareVersions(left, right string) int {
+	leftParts, rightParts := strings.Split(left, "."), strings.Split(right, ".")
+
+	for index := range min(len(leftParts), len(rightParts)) {
+		leftNumber, _ := strconv.Atoi(leftParts[index])
+		rightNumber, _ := strconv.Atoi(rightParts[index])
+
+		if order := cmp.Compare(leftNumber, rightNumber); order != 0 {
+			return order
+		}
+	}
+
+	return cmp.Compare(len(leftParts), len(rightParts))
+}
@@ -0,0 +1,54 @@
+package cephjson_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephjson"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersions_ReturnsOldestMonitorRelease(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	payload, err := os.ReadFile(filepath.Join("testdata", "versions_upgrading.json"))
+	require.NoError(t, err)
+
+	// Act
+	version, err := cephjson.ParseVersions(string(payload))
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "17.2.8", version)
+}
+
+func TestParseVersions_DropsDevelopmentSuffix(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	payload := `{"mon": {"ceph version 19.3.0-1234-gdeadbeef (deadbeef) squid (dev)": 3}}`
+
+	// Act
+	version, err := cephjson.ParseVersions(payload)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "19.3.0", version)
+}
+
+func TestParseVersions_RejectsMissingOrMalformedVersions(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	_, emptyErr := cephjson.ParseVersions(`{"osd": {"ceph version 18.2.7 (x) reef (stable)": 1}}`)
+	_, bannerErr := cephjson.ParseVersions(`{"mon": {"unknown": 1}}`)
+	_, decodeErr := cephjson.ParseVersions("not json")
+
+	// Assert
+	require.ErrorIs(t, emptyErr, cephjson.ErrNoMonVersion)
+	require.ErrorIs(t, bannerErr, domain.ErrInvalidCephVersion)
+	require.Error(t, decodeErr)
+}
@@ -0,0 +1,95 @@
+// Package cephlocal runs the ceph CLI installed on the local host.
+package cephlocal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephconf"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephjson"
+)
+
+const (
+	binaryName            = "ceph"
+	defaultCommandTimeout = 30 * time.Second
+)
+
+// CephClient executes the ceph binary found on PATH with a generated
+// ceph.conf and keyring that are removed after each command.
+type CephClient struct {
+	commandTimeout func(cluster *domain.Cluster) time.Duration
+}
+
+var _ domain.CephClient = (*CephClient)(nil)
+
+func NewCephClient(opts ...Option) *CephClient {
+	client := &CephClient{commandTimeout: nil}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+func (c *CephClient) Status(ctx context.Context, cluster *domain.Cluster) (*domain.CephStatus, error) {
+	stdout, stderr, exitCode, err := c.run(ctx, cluster, "status", "--format", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := cephjson.StatusResult(stdout, stderr, exitCode)
+	if err != nil {
+		return status, fmt.Errorf("ceph status: %w", err)
+	}
+
+	return status, nil
+}
+
+func (c *CephClient) run(
+	ctx context.Context,
+	cluster *domain.Cluster,
+	args ...string,
+) (string, string, int, error) {
+	binary, err := exec.LookPath(binaryName)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("find ceph binary: %w", err)
+	}
+
+	configDir, err := cephconf.WriteTempDir("", "cephdoctor-local-*", cluster)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("prepare cluster config: %w", err)
+	}
+
+	defer removeConfigDir(configDir)
+
+	runCtx, cancel := context.WithTimeout(ctx, c.timeoutFor(cluster))
+	defer cancel()
+
+	commandArgs := append(cephconf.Args(configDir, cluster), args...)
+
+	//nolint:gosec // The binary is resolved from PATH and arguments are passed without a shell.
+	command := exec.CommandContext(runCtx, binary, commandArgs...)
+
+	var stdout, stderr bytes.Buffer
+
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	err = command.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return stdout.String(), stderr.String(), exitErr.ExitCode(), nil
+	}
+
+	if err != nil {
+		return "", "", 0, fmt.Errorf("run ceph: %w", err)
+	}
+
+	return stdout.String(), stderr.String(), 0, nil
+}
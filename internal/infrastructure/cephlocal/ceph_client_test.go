@@ -0,0 +1,118 @@
+package cephlocal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephjson"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephlocal"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCeph echoes the generated config back as the fsid so tests can see what the client passed.
+const stubCeph = `#!/bin/sh
+conf=""
+keyring=""
+name=""
+while [ $# -gt 0 ]; do
+	case "$1" in
+	--conf) conf="$2"; shift 2 ;;
+	--keyring) keyring="$2"; shift 2 ;;
+	--name) name="$2"; shift 2 ;;
+	*) args="$args $1"; shift ;;
+	esac
+done
+if [ "$args" != " status --format json" ]; then
+	echo "unexpected args:$args" >&2
+	exit 22
+fi
+mon_host=$(sed -n 's/^ *mon_host = //p' "$conf")
+key=$(sed -n "/^\[$name\]/,/^\[/s/^ *key = //p" "$keyring")
+printf '{"fsid":"%s|%s|%s","health":{"status":"HEALTH_OK","checks":{}}}' "$mon_host" "$key" "$name"
+`
+
+func TestCephClient_StatusRunsCephFromPath(t *testing.T) {
+	// Arrange
+	installStub(t, stubCeph)
+
+	cluster, err := domain.NewCluster("cluster-a", "secret", []string{"10.0.0.1", "10.0.0.2:6789"},
+		domain.WithEntity("client.cephdoctor"))
+	require.NoError(t, err)
+
+	// Act
+	status, err := cephlocal.NewCephClient().Status(t.Context(), cluster)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1:3300 10.0.0.2:6789|secret|client.cephdoctor", status.FSID)
+	require.Equal(t, domain.HealthOK, status.Health.Status)
+}
+
+func TestCephClient_StatusReportsNonZeroExit(t *testing.T) {
+	// Arrange
+	installStub(t, "#!/bin/sh\necho 'auth failed' >&2\nexit 13\n")
+
+	cluster, err := domain.NewCluster("cluster-a", "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	// Act
+	status, err := cephlocal.NewCephClient().Status(t.Context(), cluster)
+
+	// Assert
+	require.ErrorIs(t, err, cephjson.ErrStatusExit)
+	require.Equal(t, "auth failed\n", status.Stderr)
+}
+
+func TestCephClient_StatusFailsWithoutBinary(t *testing.T) {
+	// Arrange
+	t.Setenv("PATH", t.TempDir())
+
+	cluster, err := domain.NewCluster("cluster-a", "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	// Act
+	status, err := cephlocal.NewCephClient().Status(t.Context(), cluster)
+
+	// Assert
+	require.ErrorContains(t, err, "find ceph binary")
+	require.Nil(t, status)
+}
+
+func TestCephClient_StatusHonoursCommandTimeout(t *testing.T) {
+	// Arrange
+	installStub(t, "#!/bin/sh\nexec sleep 10\n")
+
+	cluster, err := domain.NewCluster("cluster-a", "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	client := cephlocal.NewCephClient(cephlocal.WithCommandTimeout(func(*domain.Cluster) time.Duration {
+		return 100 * time.Millisecond
+	}))
+	started := time.Now()
+
+	// Act
+	_, err = client.Status(t.Context(), cluster)
+
+	// Assert
+	require.Error(t, err)
+	require.Less(t, time.Since(started), 5*time.Second)
+}
+
+func installStub(t *testing.T, script string) {
+	t.Helper()
+
+	installTool(t, "ceph", script)
+}
+
+func installTool(t *testing.T, name, script string) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	//nolint:gosec // The stub must be executable.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(script), 0o700))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
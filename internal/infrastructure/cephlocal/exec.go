@@ -0,0 +1,62 @@
+package cephlocal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephconf"
+)
+
+var _ domain.CephExecutor = (*CephClient)(nil)
+
+// Exec runs command with the tool found on PATH and streams its output. Unlike Status it has
+// no timeout of its own, so long-running commands end only when ctx does.
+func (c *CephClient) Exec(
+	ctx context.Context,
+	cluster *domain.Cluster,
+	command domain.CephCommand,
+	stdout, stderr io.Writer,
+) (int, error) {
+	binary, err := exec.LookPath(command.Tool)
+	if err != nil {
+		return 0, fmt.Errorf("find %s binary: %w", command.Tool, err)
+	}
+
+	configDir, err := cephconf.WriteTempDir("", "cephdoctor-local-*", cluster)
+	if err != nil {
+		return 0, fmt.Errorf("prepare cluster config: %w", err)
+	}
+
+	defer removeConfigDir(configDir)
+
+	//nolint:gosec // The binary is one of domain.CephTools and arguments are passed without a shell.
+	process := exec.CommandContext(ctx, binary, append(cephconf.Args(configDir, cluster), command.Args...)...)
+	process.Stdout = stdout
+	process.Stderr = stderr
+
+	err = process.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("run %s: %w", command.Tool, err)
+	}
+
+	return 0, nil
+}
+
+func removeConfigDir(dir string) {
+	err := os.RemoveAll(dir)
+	if err != nil {
+		slog.Warn("remove cluster config dir", "dir", dir, "error", err)
+	}
+}
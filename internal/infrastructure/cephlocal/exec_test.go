@@ -0,0 +1,53 @@
+package cephlocal_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephlocal"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRBD prints its arguments after the generated config options and fails like a missing image.
+const stubRBD = `#!/bin/sh
+shift 6
+echo "rbd $*"
+echo "rbd: error opening image" >&2
+exit 2
+`
+
+func TestCephClient_ExecStreamsOutputAndExitCode(t *testing.T) {
+	// Arrange
+	installTool(t, "rbd", stubRBD)
+	cluster, err := domain.NewCluster("cluster-a", "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	command, err := domain.NewCephCommand([]string{"rbd", "info", "rbd/missing"})
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	exitCode, err := cephlocal.NewCephClient().Exec(t.Context(), cluster, command, &stdout, &stderr)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, 2, exitCode)
+	require.Equal(t, "rbd info rbd/missing\n", stdout.String())
+	require.Equal(t, "rbd: error opening image\n", stderr.String())
+}
+
+func TestCephClient_ExecFailsWithoutBinary(t *testing.T) {
+	// Arrange
+	t.Setenv("PATH", t.TempDir())
+	cluster, err := domain.NewCluster("cluster-a", "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	// Act
+	_, err = cephlocal.NewCephClient().Exec(t.Context(), cluster, domain.CephCommand{Tool: "rados", Args: nil},
+		&bytes.Buffer{}, &bytes.Buffer{})
+
+	// Assert
+	require.ErrorContains(t, err, "find rados binary")
+}
@@ -0,0 +1,31 @@
+package cephpodman
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+func cleanupTempDir(configDir string, resultErr *error) {
+	removeErr := os.RemoveAll(configDir)
+	if *resultErr == nil && removeErr != nil {
+		*resultErr = fmt.Errorf("remove temp config dir: %w", removeErr)
+	}
+}
+
+func cleanupContainer(
+	ctx context.Context,
+	runtime containerRuntime,
+	containerID string,
+	timeout time.Duration,
+	resultErr *error,
+) {
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	removeErr := runtime.RemoveContainer(cleanupCtx, containerID)
+	if *resultErr == nil && removeErr != nil {
+		*resultErr = fmt.Errorf("remove container: %w", removeErr)
+	}
+}
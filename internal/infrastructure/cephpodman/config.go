@@ -0,0 +1,54 @@
+package cephpodman
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephconf"
+)
+
+// prepareConfigDir writes the cluster configuration into a fresh directory below root.
+func prepareConfigDir(root string, cluster *domain.Cluster) (string, error) {
+	configDir, err := cephconf.WriteTempDir(root, sanitizeContainerName(cluster.Name())+"-*", cluster)
+	if err != nil {
+		return "", fmt.Errorf("prepare cluster config: %w", err)
+	}
+
+	return configDir, nil
+}
+
+func sanitizeContainerName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "cluster"
+	}
+
+	var builder strings.Builder
+
+	lastDash := false
+
+	for _, r := range name {
+		isAlphaNum := r >= 'a' && r <= 'z' || r >= '0' && r <= '9'
+		if isAlphaNum {
+			builder.WriteRune(r)
+
+			lastDash = false
+
+			continue
+		}
+
+		if !lastDash {
+			builder.WriteByte('-')
+
+			lastDash = true
+		}
+	}
+
+	sanitized := strings.Trim(builder.String(), "-")
+	if sanitized == "" {
+		return "cluster"
+	}
+
+	return sanitized
+}
@@ -0,0 +1,55 @@
+package cephpodman
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephconf"
+)
+
+// execCeph runs `ceph <args>` for cluster inside the helper container.
+func (c *CephClient) execCeph(
+	ctx context.Context,
+	cluster *domain.Cluster,
+	args ...string,
+) (string, string, int, error) {
+	settings := c.settingsFor(cluster)
+
+	runtime, helper, err := c.ensureHelper(ctx, settings)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	clusterDir, err := prepareConfigDir(helper.configRoot, cluster)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	defer removeConfigDir(clusterDir)
+
+	containerDir := path.Join(helperConfigDir, filepath.Base(clusterDir))
+	command := strings.Join(append(append([]string{"ceph"}, cephconf.Args(containerDir, cluster)...), args...), " ")
+
+	execCtx, execCancel := context.WithTimeout(ctx, settings.CommandTimeout)
+	defer execCancel()
+
+	stdout, stderr, exitCode, err := runtime.ExecContainer(execCtx, helper.id, command)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("exec ceph %s: %w", strings.Join(args, " "), err)
+	}
+
+	return stdout, stderr, exitCode, nil
+}
+
+func removeConfigDir(dir string) {
+	err := os.RemoveAll(dir)
+	if err != nil {
+		slog.Warn("remove cluster config dir", "dir", dir, "error", err)
+	}
+}
@@ -0,0 +1,67 @@
+package cephpodman
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"path/filepath"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephconf"
+)
+
+var _ domain.CephExecutor = (*CephClient)(nil)
+
+// Exec runs command in the helper container for the cluster's image and streams its output.
+// Unlike Status it has no timeout of its own, so long-running commands end only when ctx does.
+func (c *CephClient) Exec(
+	ctx context.Context,
+	cluster *domain.Cluster,
+	command domain.CephCommand,
+	stdout, stderr io.Writer,
+) (int, error) {
+	_, helper, err := c.ensureHelper(ctx, c.settingsFor(cluster))
+	if err != nil {
+		return 0, err
+	}
+
+	clusterDir, err := prepareConfigDir(helper.configRoot, cluster)
+	if err != nil {
+		return 0, err
+	}
+
+	defer removeConfigDir(clusterDir)
+
+	containerDir := path.Join(helperConfigDir, filepath.Base(clusterDir))
+
+	process, err := c.podmanCommand(ctx, execArgs(helper.id, containerDir, cluster, command)...)
+	if err != nil {
+		return 0, err
+	}
+
+	process.Stdout = stdout
+	process.Stderr = stderr
+
+	err = process.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("exec %s: %w", command.Tool, err)
+	}
+
+	return 0, nil
+}
+
+// execArgs builds the podman arguments that run command in the helper with the cluster files in containerDir.
+func execArgs(helperID, containerDir string, cluster *domain.Cluster, command domain.CephCommand) []string {
+	args := append([]string{"exec", helperID, command.Tool}, cephconf.Args(containerDir, cluster)...)
+
+	return append(args, command.Args...)
+}
@@ -0,0 +1,29 @@
+//nolint:testpackage // Argument construction is tested through unexported helpers.
+package cephpodman
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecArgs_PutsClusterFilesBeforeCommandArgs(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	command, err := domain.NewCephCommand([]string{"rados", "ls", "-p", "rbd"})
+	require.NoError(t, err)
+
+	// Act
+	args := execArgs("helper-1", "/etc/cephdoctor/alpha", newTestCluster(t), command)
+
+	// Assert
+	require.Equal(t, []string{
+		"exec", "helper-1", "rados",
+		"--conf", "/etc/cephdoctor/alpha/ceph.conf",
+		"--keyring", "/etc/cephdoctor/alpha/ceph.client.admin.keyring",
+		"--name", "client.admin",
+		"ls", "-p", "rbd",
+	}, args)
+}
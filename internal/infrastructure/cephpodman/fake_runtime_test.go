@@ -0,0 +1,110 @@
+//nolint:testpackage // The podman runtime is replaced through unexported fields.
+package cephpodman
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/porun"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRuntime records the calls the client makes instead of talking to podman.
+// Pulls of slowImage announce themselves on pulling and wait until release is closed.
+type fakeRuntime struct {
+	mu       sync.Mutex
+	pulled   []string
+	created  []porun.ContainerSpec
+	started  []string
+	removed  []string
+	commands []string
+	stdout   string
+
+	slowImage string
+	pulling   chan string
+	release   chan struct{}
+}
+
+func (r *fakeRuntime) EnsureImageAvailable(_ context.Context, image string) error {
+	r.mu.Lock()
+	r.pulled = append(r.pulled, image)
+	r.mu.Unlock()
+
+	if image == r.slowImage {
+		r.pulling <- image
+		<-r.release
+	}
+
+	return nil
+}
+
+func (r *fakeRuntime) CreateContainer(_ context.Context, spec porun.ContainerSpec) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.created = append(r.created, spec)
+
+	return fmt.Sprintf("container-%d", len(r.created)), nil
+}
+
+func (r *fakeRuntime) StartContainer(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.started = append(r.started, id)
+
+	return nil
+}
+
+func (r *fakeRuntime) ExecContainer(_ context.Context, _ string, command string) (string, string, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.commands = append(r.commands, command)
+
+	return r.stdout, "", 0, nil
+}
+
+func (r *fakeRuntime) RemoveContainer(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.removed = append(r.removed, id)
+
+	return nil
+}
+
+func newFakeRuntime(stdout string) *fakeRuntime {
+	return &fakeRuntime{
+		mu:       sync.Mutex{},
+		pulled:   nil,
+		created:  nil,
+		started:  nil,
+		removed:  nil,
+		commands: nil,
+		stdout:   stdout,
+
+		slowImage: "",
+		pulling:   nil,
+		release:   nil,
+	}
+}
+
+func newFakeClient(runtime *fakeRuntime) *CephClient {
+	client := NewCephClient()
+	client.host, client.runtime = "unix:///run/podman/fake.sock", runtime
+
+	return client
+}
+
+func newTestCluster(t *testing.T) *domain.Cluster {
+	t.Helper()
+
+	cluster, err := domain.NewCluster("Cluster A", "AQBsecret==", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	return cluster
+}
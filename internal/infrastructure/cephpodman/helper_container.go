@@ -0,0 +1,95 @@
+package cephpodman
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const helperNamePrefix = "cephdoctor-helper-"
+
+// helperContainer is a long-lived container that ceph commands are exec'd into.
+// Per-cluster configuration is written below configRoot, which is mounted at helperConfigDir.
+type helperContainer struct {
+	id             string
+	configRoot     string
+	cleanupTimeout time.Duration
+}
+
+// helperSlot holds the helper container for one image. Its lock is held while the helper is created,
+// so calls for the same image wait for one pull and container while other images go ahead.
+type helperSlot struct {
+	mu     sync.Mutex
+	helper *helperContainer
+}
+
+// ensureHelper returns the shared runtime and the helper container for settings.Image,
+// pulling the image and creating the container on first use.
+func (c *CephClient) ensureHelper(ctx context.Context, settings Settings) (containerRuntime, *helperContainer, error) {
+	slot := c.helperSlot(settings.Image)
+
+	slot.mu.Lock()
+	defer slot.mu.Unlock()
+
+	runtime, err := c.ensureRuntime(ctx, settings.CommandTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if slot.helper == nil {
+		slot.helper, err = startHelper(ctx, runtime, settings)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return runtime, slot.helper, nil
+}
+
+func (c *CephClient) helperSlot(image string) *helperSlot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	slot, ok := c.helpers[image]
+	if !ok {
+		slot = &helperSlot{mu: sync.Mutex{}, helper: nil}
+		c.helpers[image] = slot
+	}
+
+	return slot
+}
+
+func startHelper(ctx context.Context, runtime containerRuntime, settings Settings) (*helperContainer, error) {
+	err := ensureImage(ctx, runtime, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	configRoot, err := os.MkdirTemp("", helperNamePrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("create helper config dir: %w", err)
+	}
+
+	// The temporary directory name is unique, so helpers created at the same time get distinct names.
+	containerName := filepath.Base(configRoot)
+
+	containerID, err := createHelperContainer(ctx, runtime, settings, configRoot, containerName)
+	if err != nil {
+		_ = os.RemoveAll(configRoot)
+
+		return nil, err
+	}
+
+	err = startContainer(ctx, runtime, settings, containerID)
+	if err != nil {
+		cleanupContainer(ctx, runtime, containerID, settings.CleanupTimeout, &err)
+		_ = os.RemoveAll(configRoot)
+
+		return nil, err
+	}
+
+	return &helperContainer{id: containerID, configRoot: configRoot, cleanupTimeout: settings.CleanupTimeout}, nil
+}
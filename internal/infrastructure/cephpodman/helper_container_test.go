@@ -0,0 +1,138 @@
+//nolint:testpackage // The podman runtime is replaced through unexported fields.
+package cephpodman
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCephClient_ReusesOneHelperPerImage(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	runtime := newFakeRuntime("")
+	client := newFakeClient(runtime)
+	reef, squid := DefaultSettings(), DefaultSettings()
+	squid.Image = "quay.io/ceph/ceph:v19.2.3"
+
+	// Act
+	_, first, firstErr := client.ensureHelper(t.Context(), reef)
+	_, again, againErr := client.ensureHelper(t.Context(), reef)
+	_, other, otherErr := client.ensureHelper(t.Context(), squid)
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, againErr)
+	require.NoError(t, otherErr)
+	require.Same(t, first, again)
+	require.NotEqual(t, first.id, other.id)
+	require.Equal(t, []string{reef.Image, squid.Image}, runtime.pulled)
+	require.Len(t, runtime.created, 2)
+	require.Equal(t, []string{first.id, other.id}, runtime.started)
+	require.Equal(t, []string{"sleep", "infinity"}, runtime.created[0].Command)
+	require.Equal(t, []string{first.configRoot + ":" + helperConfigDir + ":ro,Z"}, runtime.created[0].Volumes)
+}
+
+func TestCephClient_CreatesHelpersPerImageConcurrently(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	slow, fast := DefaultSettings(), DefaultSettings()
+	fast.Image = "quay.io/ceph/ceph:v19.2.3"
+
+	runtime := newFakeRuntime("")
+	runtime.slowImage, runtime.pulling, runtime.release = slow.Image, make(chan string, 1), make(chan struct{})
+	client := newFakeClient(runtime)
+
+	const callers = 8
+
+	helpers := make(chan *helperContainer, callers)
+	errs := make(chan error, callers)
+
+	var wg sync.WaitGroup
+
+	// Act
+	for range callers {
+		wg.Go(func() {
+			_, helper, err := client.ensureHelper(t.Context(), slow)
+			helpers <- helper
+			errs <- err
+		})
+	}
+
+	<-runtime.pulling
+
+	_, fastHelper, fastErr := client.ensureHelper(t.Context(), fast)
+
+	close(runtime.release)
+	wg.Wait()
+	close(helpers)
+	close(errs)
+
+	// Assert
+	require.NoError(t, fastErr, "another image is not held up by a slow pull")
+	require.NotNil(t, fastHelper)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	first := <-helpers
+	for helper := range helpers {
+		require.Same(t, first, helper)
+	}
+
+	require.Len(t, runtime.created, 2)
+	require.ElementsMatch(t, []string{slow.Image, fast.Image}, runtime.pulled)
+}
+
+func TestCephClient_CloseRemovesHelpers(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	runtime := newFakeRuntime("")
+	client := newFakeClient(runtime)
+
+	_, helper, err := client.ensureHelper(t.Context(), DefaultSettings())
+	require.NoError(t, err)
+
+	// Act
+	err = client.Close(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []string{helper.id}, runtime.removed)
+	require.NoDirExists(t, helper.configRoot)
+}
+
+func TestCephClient_ExecCephRunsInHelper(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	runtime := newFakeRuntime("HEALTH_OK")
+	client := newFakeClient(runtime)
+
+	defer func() { require.NoError(t, client.Close(t.Context())) }()
+
+	// Act
+	stdout, _, exitCode, err := client.execCeph(t.Context(), newTestCluster(t), "health")
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "HEALTH_OK", stdout)
+	require.Zero(t, exitCode)
+	require.Len(t, runtime.commands, 1)
+
+	fields := strings.Fields(runtime.commands[0])
+	require.Equal(t, "ceph", fields[0])
+	require.True(t, strings.HasPrefix(fields[2], helperConfigDir+"/cluster-a-"), fields[2])
+	require.Equal(t, []string{"--name", "client.admin", "health"}, fields[5:])
+
+	entries, err := os.ReadDir(client.helpers[DefaultSettings().Image].helper.configRoot)
+	require.NoError(t, err)
+	require.Empty(t, entries, "the per-call config dir is removed after the command")
+}
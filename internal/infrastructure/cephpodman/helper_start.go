@@ -0,0 +1,42 @@
+package cephpodman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neatflowcv/porun"
+)
+
+func createHelperContainer(
+	ctx context.Context,
+	runtime containerRuntime,
+	settings Settings,
+	configRoot, containerName string,
+) (string, error) {
+	createCtx, createCancel := context.WithTimeout(ctx, settings.CommandTimeout)
+	defer createCancel()
+
+	containerID, err := runtime.CreateContainer(createCtx, porun.ContainerSpec{
+		Name:    containerName,
+		Image:   settings.Image,
+		Command: []string{"sleep", "infinity"},
+		Volumes: []string{fmt.Sprintf("%s:%s:ro,Z", configRoot, helperConfigDir)},
+	})
+	if err != nil {
+		return "", fmt.Errorf("create container: %w", err)
+	}
+
+	return containerID, nil
+}
+
+func startContainer(ctx context.Context, runtime containerRuntime, settings Settings, containerID string) error {
+	startCtx, startCancel := context.WithTimeout(ctx, settings.CommandTimeout)
+	defer startCancel()
+
+	err := runtime.StartContainer(startCtx, containerID)
+	if err != nil {
+		return fmt.Errorf("start container: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package cephpodman
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+const podmanBinary = "podman"
+
+// podmanCommand prepares a podman CLI call against the service the runtime is connected to.
+// The CLI is used where porun cannot help: streaming exec output and attaching a terminal.
+func (c *CephClient) podmanCommand(ctx context.Context, args ...string) (*exec.Cmd, error) {
+	binary, err := exec.LookPath(podmanBinary)
+	if err != nil {
+		return nil, fmt.Errorf("find podman binary: %w", err)
+	}
+
+	c.mu.Lock()
+	host := c.host
+	c.mu.Unlock()
+
+	//nolint:gosec // The binary is resolved from PATH and arguments are passed without a shell.
+	return exec.CommandContext(ctx, binary, append([]string{"--url", host}, args...)...), nil
+}
@@ -0,0 +1,87 @@
+package cephpodman
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/neatflowcv/porun"
+)
+
+// containerRuntime is the part of porun.Runtime the client uses.
+type containerRuntime interface {
+	CreateContainer(ctx context.Context, spec porun.ContainerSpec) (string, error)
+	StartContainer(ctx context.Context, id string) error
+	ExecContainer(ctx context.Context, id string, command string) (string, string, int, error)
+	RemoveContainer(ctx context.Context, id string) error
+	EnsureImageAvailable(ctx context.Context, image string) error
+}
+
+// ensureRuntime returns the shared runtime, connecting on first use. Concurrent first calls wait
+// for a single connection attempt.
+func (c *CephClient) ensureRuntime(ctx context.Context, timeout time.Duration) (containerRuntime, error) {
+	c.connectMu.Lock()
+	defer c.connectMu.Unlock()
+
+	c.mu.Lock()
+	connected := c.runtime
+	c.mu.Unlock()
+
+	if connected != nil {
+		return connected, nil
+	}
+
+	host, err := c.resolveHost()
+	if err != nil {
+		return nil, fmt.Errorf("resolve podman host: %w", err)
+	}
+
+	runtimeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	runtime, err := c.newRuntime(runtimeCtx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.host, c.runtime = host, runtime
+	c.mu.Unlock()
+
+	return runtime, nil
+}
+
+func (c *CephClient) resolveHost() (string, error) {
+	if host := os.Getenv("CONTAINER_HOST"); host != "" {
+		return host, nil
+	}
+
+	host, err := porun.DetectPodmanURI()
+	if err != nil {
+		return "", fmt.Errorf("detect podman URI: %w", err)
+	}
+
+	return host, nil
+}
+
+func (c *CephClient) newRuntime(ctx context.Context, host string) (*porun.PodmanRuntime, error) {
+	runtime, err := porun.NewPodmanRuntime(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("create podman runtime: %w", err)
+	}
+
+	return runtime, nil
+}
+
+func ensureImage(ctx context.Context, runtime containerRuntime, settings Settings) error {
+	imageCtx, imageCancel := context.WithTimeout(ctx, settings.CommandTimeout)
+	defer imageCancel()
+
+	err := runtime.EnsureImageAvailable(imageCtx, settings.Image)
+	if err != nil {
+		return fmt.Errorf("ensure image %s: %w", settings.Image, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package cephpodman
+
+import (
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+const (
+	defaultImage          = "quay.io/ceph/ceph:v18.2.7"
+	defaultCommandTimeout = 30 * time.Second
+	defaultCleanupTimeout = 15 * time.Second
+)
+
+// releaseImages maps ceph releases to the upstream image used for clusters running them, looked
+// up by the longest matching version prefix. Clusters with no cached or known release get defaultImage.
+//
+//nolint:gochecknoglobals // The registry is a fixed table of upstream images.
+var releaseImages = map[string]string{
+	"16": "quay.io/ceph/ceph:v16.2.15",
+	"17": "quay.io/ceph/ceph:v17.2.8",
+	"18": defaultImage,
+	"19": "quay.io/ceph/ceph:v19.2.3",
+}
+
+// Settings chooses the container image and timeouts used for a cluster. Image is used as is,
+// while DefaultImage only replaces defaultImage for clusters whose release releaseImages does not know.
+type Settings struct {
+	Image          string
+	DefaultImage   string
+	CommandTimeout time.Duration
+	CleanupTimeout time.Duration
+}
+
+// Option configures optional CephClient behaviour.
+type Option func(*CephClient)
+
+// DefaultSettings returns the built-in image and timeouts.
+func DefaultSettings() Settings {
+	return Settings{
+		Image:          defaultImage,
+		DefaultImage:   defaultImage,
+		CommandTimeout: defaultCommandTimeout,
+		CleanupTimeout: defaultCleanupTimeout,
+	}
+}
+
+// WithSettings chooses the settings per cluster. Zero fields fall back to DefaultSettings, except
+// that an unset image follows the cluster's cached release when releaseImages knows it, and
+// DefaultImage otherwise.
+func WithSettings(settings func(cluster *domain.Cluster) Settings) Option {
+	return func(c *CephClient) {
+		c.settings = settings
+	}
+}
+
+func (c *CephClient) settingsFor(cluster *domain.Cluster) Settings {
+	resolved := DefaultSettings()
+
+	var custom Settings
+	if c.settings != nil {
+		custom = c.settings(cluster)
+	}
+
+	if custom.DefaultImage != "" {
+		resolved.Image, resolved.DefaultImage = custom.DefaultImage, custom.DefaultImage
+	}
+
+	if image, ok := domain.LookupByCephVersion(releaseImages, cluster.CephVersion()); ok {
+		resolved.Image = image
+	}
+
+	if custom.Image != "" {
+		resolved.Image = custom.Image
+	}
+
+	if custom.CommandTimeout > 0 {
+		resolved.CommandTimeout = custom.CommandTimeout
+	}
+
+	if custom.CleanupTimeout > 0 {
+		resolved.CleanupTimeout = custom.CleanupTimeout
+	}
+
+	return resolved
+}
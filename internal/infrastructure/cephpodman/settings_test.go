@@ -0,0 +1,77 @@
+//nolint:testpackage // Settings resolution is tested through unexported helpers.
+package cephpodman
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCephClient_SettingsForImagePrecedence(t *testing.T) {
+	t.Parallel()
+
+	const (
+		pinned  = "mirror.local/ceph/ceph:pinned"
+		mirror  = "mirror.local/ceph/ceph:v18"
+		quincy  = "quay.io/ceph/ceph:v17.2.8"
+		unknown = "15.2.17"
+	)
+
+	tests := []struct {
+		name    string
+		custom  *Settings
+		version string
+		want    string
+	}{
+		{name: "built-in default", custom: nil, version: "", want: defaultImage},
+		{name: "release image", custom: nil, version: "17.2.5", want: quincy},
+		{name: "unknown release", custom: nil, version: unknown, want: defaultImage},
+		{name: "defaults.image", custom: imageSettings("", mirror), version: unknown, want: mirror},
+		{name: "release beats defaults.image", custom: imageSettings("", mirror), version: "17.2.5", want: quincy},
+		{name: "per-cluster image", custom: imageSettings(pinned, mirror), version: "17.2.5", want: pinned},
+		{name: "per-cluster without version", custom: imageSettings(pinned, ""), version: "", want: pinned},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			client := NewCephClient()
+			if test.custom != nil {
+				client = NewCephClient(WithSettings(func(*domain.Cluster) Settings { return *test.custom }))
+			}
+
+			cluster, err := domain.NewCluster("alpha", "secret", []string{"10.0.0.1"},
+				domain.WithCephVersion(test.version))
+			require.NoError(t, err)
+
+			// Act
+			settings := client.settingsFor(cluster)
+
+			// Assert
+			require.Equal(t, test.want, settings.Image)
+		})
+	}
+}
+
+func TestCephClient_SettingsForTimeouts(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	custom := Settings{Image: "", DefaultImage: "", CommandTimeout: time.Minute, CleanupTimeout: 0}
+	client := NewCephClient(WithSettings(func(*domain.Cluster) Settings { return custom }))
+
+	// Act
+	settings := client.settingsFor(newTestCluster(t))
+
+	// Assert
+	require.Equal(t, time.Minute, settings.CommandTimeout)
+	require.Equal(t, defaultCleanupTimeout, settings.CleanupTimeout)
+}
+
+func imageSettings(image, defaultImage string) *Settings {
+	return &Settings{Image: image, DefaultImage: defaultImage, CommandTimeout: 0, CleanupTimeout: 0}
+}
@@ -0,0 +1,72 @@
+package cephpodman
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephconf"
+	"golang.org/x/term"
+)
+
+const shellCommand = "/bin/bash"
+
+// Shell starts an interactive shell in the helper container for the cluster's image, attached
+// to the given streams. CEPH_ARGS points ceph, rados, rbd and radosgw-admin at the generated
+// configuration, so they work without options. A terminal is allocated when stdin is one.
+// The helper container is removed by Close, like after any other command.
+func (c *CephClient) Shell(ctx context.Context, cluster *domain.Cluster, stdin, stdout, stderr *os.File) (int, error) {
+	_, helper, err := c.ensureHelper(ctx, c.settingsFor(cluster))
+	if err != nil {
+		return 0, err
+	}
+
+	clusterDir, err := prepareConfigDir(helper.configRoot, cluster)
+	if err != nil {
+		return 0, err
+	}
+
+	defer removeConfigDir(clusterDir)
+
+	containerDir := path.Join(helperConfigDir, filepath.Base(clusterDir))
+	tty := term.IsTerminal(int(stdin.Fd())) //nolint:gosec // File descriptors fit in int.
+
+	process, err := c.podmanCommand(ctx, shellArgs(helper.id, containerDir, cluster, tty)...)
+	if err != nil {
+		return 0, err
+	}
+
+	process.Stdin, process.Stdout, process.Stderr = stdin, stdout, stderr
+
+	err = process.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("run shell: %w", err)
+	}
+
+	return 0, nil
+}
+
+// shellArgs builds the podman arguments for the shell. CEPH_ARGS carries the cluster files in containerDir,
+// and a terminal is only requested when tty is set.
+func shellArgs(helperID, containerDir string, cluster *domain.Cluster, tty bool) []string {
+	cephArgs := strings.Join(cephconf.Args(containerDir, cluster), " ")
+	args := []string{"exec", "--interactive", "--env", "CEPH_ARGS=" + cephArgs}
+
+	if tty {
+		args = append(args, "--tty")
+	}
+
+	return append(args, helperID, shellCommand)
+}
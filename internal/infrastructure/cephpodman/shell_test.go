@@ -0,0 +1,25 @@
+//nolint:testpackage // Argument construction is tested through unexported helpers.
+package cephpodman
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShellArgs_RequestsTerminalOnlyWhenInteractive(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cluster := newTestCluster(t)
+	cephArgs := "CEPH_ARGS=--conf /etc/cephdoctor/alpha/ceph.conf" +
+		" --keyring /etc/cephdoctor/alpha/ceph.client.admin.keyring --name client.admin"
+
+	// Act
+	interactive := shellArgs("helper-1", "/etc/cephdoctor/alpha", cluster, true)
+	piped := shellArgs("helper-1", "/etc/cephdoctor/alpha", cluster, false)
+
+	// Assert
+	require.Equal(t, []string{"exec", "--interactive", "--env", cephArgs, "--tty", "helper-1", "/bin/bash"}, interactive)
+	require.Equal(t, []string{"exec", "--interactive", "--env", cephArgs, "helper-1", "/bin/bash"}, piped)
+}
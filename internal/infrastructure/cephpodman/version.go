@@ -0,0 +1,50 @@
+package cephpodman
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephjson"
+)
+
+var errVersionsExit = errors.New("ceph versions returned non-zero exit status")
+
+// detectVersion asks a cluster without a cached release which one it runs, so that the caller
+// can cache it and later runs start a matching image. It returns "" when the release is already
+// cached or cannot be detected; a failure is only logged because it costs nothing but the match.
+func (c *CephClient) detectVersion(ctx context.Context, cluster *domain.Cluster) string {
+	if cluster.CephVersion() != "" {
+		return ""
+	}
+
+	version, err := c.versions(ctx, cluster)
+	if err != nil {
+		slog.Warn("detect ceph version", "cluster", cluster.Name(), "error", err)
+
+		return ""
+	}
+
+	return version
+}
+
+func (c *CephClient) versions(ctx context.Context, cluster *domain.Cluster) (string, error) {
+	stdout, stderr, exitCode, err := c.execCeph(ctx, cluster, "versions", "--format", "json")
+	if err != nil {
+		return "", err
+	}
+
+	if exitCode != 0 {
+		return "", fmt.Errorf("%w: %d: %s", errVersionsExit, exitCode, strings.TrimSpace(stderr))
+	}
+
+	version, err := cephjson.ParseVersions(stdout)
+	if err != nil {
+		return "", fmt.Errorf("ceph versions: %w", err)
+	}
+
+	return version, nil
+}
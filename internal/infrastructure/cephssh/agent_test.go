@@ -0,0 +1,88 @@
+package cephssh_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephssh"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func TestCephClient_ClosesAgentConnectionAfterHandshake(t *testing.T) {
+	// Arrange
+	installStub(t, stubCeph)
+
+	publicKey, open := startTestAgent(t)
+	server := startTestServer(t, publicKey)
+	client := cephssh.NewCephClient(cephssh.Config{
+		IdentityFiles:   []string{filepath.Join(t.TempDir(), "missing")},
+		KnownHostsFiles: []string{server.writeKnownHosts(t)},
+		UseAgent:        true,
+	})
+	cluster := newSSHCluster(t, server.address)
+
+	// Act
+	_, firstErr := client.Status(t.Context(), cluster)
+	<-server.commands
+	_, secondErr := client.Status(t.Context(), cluster)
+	<-server.commands
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.Eventually(t, func() bool { return open.Load() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+// startTestAgent serves a fresh key on SSH_AUTH_SOCK and counts the connections still open.
+func startTestAgent(t *testing.T) (ssh.PublicKey, *atomic.Int32) {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keyring := agent.NewKeyring()
+	require.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: private}))
+
+	// Socket paths are limited to about 100 bytes, which t.TempDir can exceed.
+	dir, err := os.MkdirTemp("", "agent")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	t.Setenv("SSH_AUTH_SOCK", socket)
+
+	var open atomic.Int32
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			open.Add(1)
+
+			go func() {
+				defer open.Add(-1)
+
+				_ = agent.ServeAgent(keyring, conn)
+			}()
+		}
+	}()
+
+	signer, err := ssh.NewSignerFromKey(private)
+	require.NoError(t, err)
+
+	return signer.PublicKey(), &open
+}
@@ -0,0 +1,64 @@
+package cephssh
+
+import (
+	"log/slog"
+	"net"
+	"os"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// authMethods returns the agent and identity file methods that are available, and a func
+// closing the agent connection.
+func (c Config) authMethods() ([]ssh.AuthMethod, func()) {
+	methods := make([]ssh.AuthMethod, 0)
+	closeAgent := func() {}
+
+	if socket := os.Getenv("SSH_AUTH_SOCK"); c.UseAgent && socket != "" {
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			slog.Warn("connect ssh agent", "error", err)
+		} else {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+			closeAgent = func() { _ = conn.Close() }
+		}
+	}
+
+	if signers := c.identitySigners(); len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+
+	return methods, closeAgent
+}
+
+func (c Config) identitySigners() []ssh.Signer {
+	signers := make([]ssh.Signer, 0)
+
+	for _, path := range c.identityFiles() {
+		//nolint:gosec // Identity paths come from the operator's flags or ~/.ssh.
+		payload, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		signer, err := ssh.ParsePrivateKey(payload)
+		if err != nil {
+			slog.Warn("skip ssh identity", "path", path, "error", err)
+
+			continue
+		}
+
+		signers = append(signers, signer)
+	}
+
+	return signers
+}
+
+func (c Config) identityFiles() []string {
+	if len(c.IdentityFiles) > 0 {
+		return c.IdentityFiles
+	}
+
+	return defaultSSHFiles("id_ed25519", "id_ecdsa", "id_rsa")
+}
@@ -0,0 +1,93 @@
+// Package cephssh runs ceph commands on a cluster's admin node over SSH.
+package cephssh
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephjson"
+	"golang.org/x/crypto/ssh"
+)
+
+const defaultCommandTimeout = 30 * time.Second
+
+var errMissingSSHTarget = errors.New("cluster has no ssh target")
+
+// CephClient connects to the cluster's SSH target and runs the admin node's ceph binary
+// with a generated ceph.conf and keyring that live only for the duration of the command.
+type CephClient struct {
+	config         Config
+	commandTimeout func(cluster *domain.Cluster) time.Duration
+}
+
+var _ domain.CephClient = (*CephClient)(nil)
+
+func NewCephClient(config Config, opts ...Option) *CephClient {
+	client := &CephClient{config: config, commandTimeout: nil}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+func (c *CephClient) Status(ctx context.Context, cluster *domain.Cluster) (*domain.CephStatus, error) {
+	stdout, stderr, exitCode, err := c.run(ctx, cluster, "status", "--format", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := cephjson.StatusResult(stdout, stderr, exitCode)
+	if err != nil {
+		return status, fmt.Errorf("ceph status: %w", err)
+	}
+
+	return status, nil
+}
+
+func (c *CephClient) run(
+	ctx context.Context,
+	cluster *domain.Cluster,
+	args ...string,
+) (string, string, int, error) {
+	runCtx, cancel := context.WithTimeout(ctx, c.timeoutFor(cluster))
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	exitCode, err := c.runTool(runCtx, cluster, "ceph", args, &stdout, &stderr)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	return stdout.String(), stderr.String(), exitCode, nil
+}
+
+func (c *CephClient) dial(ctx context.Context, target *domain.SSHTarget) (*ssh.Client, error) {
+	clientConfig, closeAgent, err := c.config.clientConfig(target.User())
+	if err != nil {
+		return nil, err
+	}
+	defer closeAgent()
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", target.Address())
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", target, err)
+	}
+
+	sshConn, channels, requests, err := ssh.NewClientConn(conn, target.Address(), clientConfig)
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("ssh handshake with %s: %w", target, err)
+	}
+
+	return ssh.NewClient(sshConn, channels, requests), nil
+}
@@ -0,0 +1,165 @@
+package cephssh_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephjson"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephssh"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCeph reports the generated mon_host, key and entity back through the fsid field.
+const stubCeph = `#!/bin/sh
+while [ $# -gt 0 ]; do
+	case "$1" in
+	--conf) conf="$2"; shift 2 ;;
+	--keyring) keyring="$2"; shift 2 ;;
+	--name) name="$2"; shift 2 ;;
+	*) args="$args $1"; shift ;;
+	esac
+done
+if [ "$args" != " status --format json" ]; then
+	echo "unexpected args:$args" >&2
+	exit 22
+fi
+mon_host=$(sed -n 's/^ *mon_host = //p' "$conf")
+key=$(sed -n "/^\[$name\]/,/^\[/s/^ *key = //p" "$keyring")
+printf '{"fsid":"%s|%s|%s","health":{"status":"HEALTH_OK","checks":{}}}' "$mon_host" "$key" "$name"
+`
+
+func TestCephClient_StatusRunsCephOnAdminNode(t *testing.T) {
+	// Arrange
+	installStub(t, stubCeph)
+
+	identity, publicKey := newIdentity(t)
+	server := startTestServer(t, publicKey)
+	client := cephssh.NewCephClient(cephssh.Config{
+		IdentityFiles:   []string{identity},
+		KnownHostsFiles: []string{server.writeKnownHosts(t)},
+		UseAgent:        false,
+	})
+	cluster := newSSHCluster(t, server.address)
+
+	// Act
+	status, err := client.Status(t.Context(), cluster)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1:3300|secret|client.admin", status.FSID)
+	require.Equal(t, "sh -s", <-server.commands)
+}
+
+func TestCephClient_StatusPropagatesRemoteExitCode(t *testing.T) {
+	// Arrange
+	installStub(t, "#!/bin/sh\necho 'auth failed' >&2\nexit 13\n")
+
+	identity, publicKey := newIdentity(t)
+	server := startTestServer(t, publicKey)
+	client := cephssh.NewCephClient(cephssh.Config{
+		IdentityFiles:   []string{identity},
+		KnownHostsFiles: []string{server.writeKnownHosts(t)},
+		UseAgent:        false,
+	})
+
+	// Act
+	status, err := client.Status(t.Context(), newSSHCluster(t, server.address))
+
+	// Assert
+	require.ErrorIs(t, err, cephjson.ErrStatusExit)
+	require.Equal(t, "auth failed\n", status.Stderr)
+}
+
+func TestCephClient_StatusHonoursCommandTimeout(t *testing.T) {
+	// Arrange
+	installStub(t, "#!/bin/sh\nexec sleep 10\n")
+
+	identity, publicKey := newIdentity(t)
+	server := startTestServer(t, publicKey)
+	client := cephssh.NewCephClient(cephssh.Config{
+		IdentityFiles:   []string{identity},
+		KnownHostsFiles: []string{server.writeKnownHosts(t)},
+		UseAgent:        false,
+	}, cephssh.WithCommandTimeout(func(*domain.Cluster) time.Duration { return 200 * time.Millisecond }))
+	started := time.Now()
+
+	// Act
+	_, err := client.Status(t.Context(), newSSHCluster(t, server.address))
+
+	// Assert
+	require.Error(t, err)
+	require.Less(t, time.Since(started), 5*time.Second)
+}
+
+func TestCephClient_RejectsUnknownHostKey(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	identity, publicKey := newIdentity(t)
+	server := startTestServer(t, publicKey)
+	emptyKnownHosts := filepath.Join(t.TempDir(), "known_hosts")
+	require.NoError(t, os.WriteFile(emptyKnownHosts, nil, 0o600))
+
+	client := cephssh.NewCephClient(cephssh.Config{
+		IdentityFiles:   []string{identity},
+		KnownHostsFiles: []string{emptyKnownHosts},
+		UseAgent:        false,
+	})
+
+	// Act
+	status, err := client.Status(t.Context(), newSSHCluster(t, server.address))
+
+	// Assert
+	require.ErrorContains(t, err, "ssh handshake")
+	require.Nil(t, status)
+}
+
+func TestCephClient_RequiresSSHTarget(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cluster, err := domain.NewCluster("cluster-a", "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	client := cephssh.NewCephClient(cephssh.Config{IdentityFiles: nil, KnownHostsFiles: nil, UseAgent: false})
+
+	// Act
+	status, err := client.Status(t.Context(), cluster)
+
+	// Assert
+	require.ErrorContains(t, err, "cluster has no ssh target")
+	require.Nil(t, status)
+}
+
+func newSSHCluster(t *testing.T, address string) *domain.Cluster {
+	t.Helper()
+
+	target, err := domain.ParseSSHTarget("ceph@" + address)
+	require.NoError(t, err)
+
+	cluster, err := domain.NewCluster("cluster-a", "secret", []string{"10.0.0.1"},
+		domain.WithBackend(domain.BackendSSH), domain.WithSSHTarget(target))
+	require.NoError(t, err)
+
+	return cluster
+}
+
+func installStub(t *testing.T, script string) {
+	t.Helper()
+
+	installTool(t, "ceph", script)
+}
+
+func installTool(t *testing.T, name, script string) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	//nolint:gosec // The stub must be executable.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(script), 0o700))
+	t.Setenv("PATH", strings.Join([]string{dir, os.Getenv("PATH")}, string(os.PathListSeparator)))
+}
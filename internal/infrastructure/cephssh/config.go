@@ -0,0 +1,70 @@
+package cephssh
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+var errNoAuthMethods = errors.New("no ssh identity or agent available")
+
+// Config selects how the client authenticates and verifies hosts.
+// Empty lists fall back to the usual files under ~/.ssh.
+type Config struct {
+	IdentityFiles   []string
+	KnownHostsFiles []string
+	UseAgent        bool
+}
+
+// clientConfig returns the configuration for one connection and a func releasing the agent
+// connection it may hold, which is only needed until the handshake completes.
+func (c Config) clientConfig(user string) (*ssh.ClientConfig, func(), error) {
+	hostKeyCallback, err := knownhosts.New(c.knownHostsFiles()...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load known_hosts: %w", err)
+	}
+
+	auth, closeAgent := c.authMethods()
+	if len(auth) == 0 {
+		closeAgent()
+
+		return nil, nil, errNoAuthMethods
+	}
+
+	return &ssh.ClientConfig{
+		Config:            ssh.Config{},
+		User:              user,
+		Auth:              auth,
+		HostKeyCallback:   hostKeyCallback,
+		BannerCallback:    nil,
+		ClientVersion:     "",
+		HostKeyAlgorithms: nil,
+		Timeout:           0, // The connection is dialled with the caller's context instead.
+	}, closeAgent, nil
+}
+
+func (c Config) knownHostsFiles() []string {
+	if len(c.KnownHostsFiles) > 0 {
+		return c.KnownHostsFiles
+	}
+
+	return defaultSSHFiles("known_hosts")
+}
+
+func defaultSSHFiles(names ...string) []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, filepath.Join(home, ".ssh", name))
+	}
+
+	return paths
+}
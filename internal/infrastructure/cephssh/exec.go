@@ -0,0 +1,71 @@
+package cephssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"golang.org/x/crypto/ssh"
+)
+
+var _ domain.CephExecutor = (*CephClient)(nil)
+
+// Exec runs command on the cluster's admin node and streams its output. Unlike Status it has
+// no timeout of its own, so long-running commands end only when ctx does.
+func (c *CephClient) Exec(
+	ctx context.Context,
+	cluster *domain.Cluster,
+	command domain.CephCommand,
+	stdout, stderr io.Writer,
+) (int, error) {
+	return c.runTool(ctx, cluster, command.Tool, command.Args, stdout, stderr)
+}
+
+// runTool runs tool with args on the cluster's admin node, writing its output to stdout and stderr.
+func (c *CephClient) runTool(
+	ctx context.Context,
+	cluster *domain.Cluster,
+	tool string,
+	args []string,
+	stdout, stderr io.Writer,
+) (int, error) {
+	target := cluster.SSHTarget()
+	if target == nil {
+		return 0, fmt.Errorf("%w: %s", errMissingSSHTarget, cluster.Name())
+	}
+
+	client, err := c.dial(ctx, target)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return 0, fmt.Errorf("open ssh session: %w", err)
+	}
+	defer session.Close()
+
+	session.Stdin = strings.NewReader(buildScript(cluster, tool, args))
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	stop := context.AfterFunc(ctx, func() { _ = client.Close() })
+	defer stop()
+
+	err = session.Run(remoteShell)
+
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitStatus(), nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("run remote %s on %s: %w", tool, target, err)
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,72 @@
+package cephssh_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephssh"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCephClient_ExecRunsToolOnAdminNode(t *testing.T) {
+	// Arrange
+	installTool(t, "rados", "#!/bin/sh\nshift 6\necho \"rados $*\"\necho 'pool not found' >&2\nexit 2\n")
+
+	identity, publicKey := newIdentity(t)
+	server := startTestServer(t, publicKey)
+	client := cephssh.NewCephClient(cephssh.Config{
+		IdentityFiles:   []string{identity},
+		KnownHostsFiles: []string{server.writeKnownHosts(t)},
+		UseAgent:        false,
+	})
+
+	command, err := domain.NewCephCommand([]string{"rados", "ls", "-p", "it's"})
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	exitCode, err := client.Exec(t.Context(), newSSHCluster(t, server.address), command, &stdout, &stderr)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, 2, exitCode)
+	require.Equal(t, "rados ls -p it's\n", stdout.String())
+	require.Equal(t, "pool not found\n", stderr.String())
+}
+
+func TestCephClient_ExecWritesKeyringVerbatim(t *testing.T) {
+	// Arrange
+	installTool(t, "ceph", "#!/bin/sh\ncat \"$4\"\n")
+
+	identity, publicKey := newIdentity(t)
+	server := startTestServer(t, publicKey)
+	client := cephssh.NewCephClient(cephssh.Config{
+		IdentityFiles:   []string{identity},
+		KnownHostsFiles: []string{server.writeKnownHosts(t)},
+		UseAgent:        false,
+	})
+
+	target, err := domain.ParseSSHTarget("ceph@" + server.address)
+	require.NoError(t, err)
+
+	hostile := `AQB'CEPHDOCTOR_EOF"$(echo pwned)` + "`id`"
+	cluster, err := domain.NewCluster("cluster-a", hostile, []string{"10.0.0.1"},
+		domain.WithBackend(domain.BackendSSH), domain.WithSSHTarget(target))
+	require.NoError(t, err)
+
+	command, err := domain.NewCephCommand([]string{"ceph", "health"})
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	exitCode, err := client.Exec(t.Context(), cluster, command, &stdout, &stderr)
+
+	// Assert
+	require.NoError(t, err)
+	require.Zero(t, exitCode)
+	require.Equal(t, "[client.admin]\n        key = "+hostile+"\n", stdout.String())
+	require.Empty(t, stderr.String())
+}
@@ -0,0 +1,28 @@
+package cephssh
+
+import (
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// Option configures optional CephClient behaviour.
+type Option func(*CephClient)
+
+// WithCommandTimeout chooses the status command timeout per cluster. A zero timeout falls back
+// to defaultCommandTimeout.
+func WithCommandTimeout(timeout func(cluster *domain.Cluster) time.Duration) Option {
+	return func(c *CephClient) {
+		c.commandTimeout = timeout
+	}
+}
+
+func (c *CephClient) timeoutFor(cluster *domain.Cluster) time.Duration {
+	if c.commandTimeout != nil {
+		if timeout := c.commandTimeout(cluster); timeout > 0 {
+			return timeout
+		}
+	}
+
+	return defaultCommandTimeout
+}
@@ -0,0 +1,46 @@
+package cephssh
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/cephconf"
+)
+
+const remoteShell = "sh -s"
+
+// buildScript renders the shell script that runs tool with args, fed to the remote shell on stdin.
+// Secrets travel on stdin rather than the command line so they never show up in ps, and the files are
+// written from base64 so nothing in the config or keyring is ever read by the shell.
+func buildScript(cluster *domain.Cluster, tool string, args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		quoted = append(quoted, shellQuote(arg))
+	}
+
+	keyringFile := cephconf.KeyringFile(cluster.Entity())
+
+	lines := []string{
+		"set -e",
+		"umask 077",
+		`dir=$(mktemp -d)`,
+		`trap 'rm -rf "$dir"' EXIT`,
+		writeFileLine(cephconf.ConfigFile, cephconf.BuildConfig(cluster)),
+		writeFileLine(keyringFile, cephconf.BuildKeyring(cluster)),
+		shellQuote(tool) + ` --conf "$dir/` + cephconf.ConfigFile + `" --keyring "$dir/` + keyringFile + `" --name ` +
+			shellQuote(cluster.Entity()) + " " + strings.Join(quoted, " "),
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func writeFileLine(name, content string) string {
+	encoded := base64.StdEncoding.EncodeToString([]byte(content))
+
+	return `printf %s ` + shellQuote(encoded) + ` | base64 -d > "$dir/` + name + `"`
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
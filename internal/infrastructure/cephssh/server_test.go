@@ -0,0 +1,164 @@
+package cephssh_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+var errUnauthorized = errors.New("unauthorized")
+
+// testServer is an in-process SSH server that runs exec requests with the local sh.
+type testServer struct {
+	address  string
+	hostKey  ssh.PublicKey
+	commands chan string
+}
+
+func startTestServer(t *testing.T, authorized ssh.PublicKey) *testServer {
+	t.Helper()
+
+	_, hostPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivate)
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if bytes.Equal(key.Marshal(), authorized.Marshal()) {
+				return &ssh.Permissions{}, nil
+			}
+
+			return nil, errUnauthorized
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	server := &testServer{
+		address:  listener.Addr().String(),
+		hostKey:  hostSigner.PublicKey(),
+		commands: make(chan string, 1),
+	}
+
+	go server.serve(listener, config)
+
+	return server
+}
+
+func (s *testServer) serve(listener net.Listener, config *ssh.ServerConfig) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handleConn(conn, config)
+	}
+}
+
+func (s *testServer) handleConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		_ = conn.Close()
+
+		return
+	}
+
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "session only")
+
+			continue
+		}
+
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		go s.handleSession(channel, channelRequests)
+	}
+}
+
+func (s *testServer) handleSession(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+
+	for request := range requests {
+		if request.Type != "exec" {
+			_ = request.Reply(false, nil)
+
+			continue
+		}
+
+		var payload struct{ Command string }
+
+		_ = ssh.Unmarshal(request.Payload, &payload)
+		_ = request.Reply(true, nil)
+		s.commands <- payload.Command
+
+		//nolint:gosec // The test server executes what the client under test sent.
+		command := exec.Command("sh", "-c", payload.Command)
+		command.Stdin = channel
+		command.Stdout = channel
+		command.Stderr = channel.Stderr()
+
+		status := struct{ Status uint32 }{Status: 0}
+
+		var exitErr *exec.ExitError
+		if err := command.Run(); errors.As(err, &exitErr) {
+			status.Status = uint32(exitErr.ExitCode()) //nolint:gosec // Exit codes fit in uint32.
+		}
+
+		_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(&status))
+
+		return
+	}
+}
+
+// writeKnownHosts records the server host key and returns the known_hosts path.
+func (s *testServer) writeKnownHosts(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{s.address}, s.hostKey) + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(line), 0o600))
+
+	return path
+}
+
+// newIdentity writes a fresh private key and returns its path and public key.
+func newIdentity(t *testing.T) (string, ssh.PublicKey) {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	block, err := ssh.MarshalPrivateKey(private, "")
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromKey(private)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "id_ed25519")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0o600))
+
+	return path, signer.PublicKey()
+}
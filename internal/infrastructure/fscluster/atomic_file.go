@@ -0,0 +1,75 @@
+package fscluster
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+var errShortWrite = errors.New("write temporary file: short write")
+
+func writeFileAtomically(path string, payload []byte) error {
+	dir := filepath.Dir(path)
+
+	tmpFile, err := os.CreateTemp(dir, tempFilePrefix+"*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+
+	tmpFilePath := tmpFile.Name()
+
+	err = writeAndCloseTemporaryFile(tmpFile, payload)
+	if err != nil {
+		_ = os.Remove(tmpFilePath)
+
+		return err
+	}
+
+	err = os.Rename(tmpFilePath, path)
+	if err != nil {
+		_ = os.Remove(tmpFilePath)
+
+		return fmt.Errorf("rename temporary file: %w", err)
+	}
+
+	return nil
+}
+
+func writeAndCloseTemporaryFile(tmpFile *os.File, payload []byte) error {
+	err := tmpFile.Chmod(filePerm)
+	if err != nil {
+		_ = tmpFile.Close()
+
+		return fmt.Errorf("set file permission: %w", err)
+	}
+
+	written, err := io.Copy(tmpFile, bytes.NewReader(payload))
+	if err != nil {
+		_ = tmpFile.Close()
+
+		return fmt.Errorf("write temporary file: %w", err)
+	}
+
+	if written != int64(len(payload)) {
+		_ = tmpFile.Close()
+
+		return errShortWrite
+	}
+
+	err = tmpFile.Sync()
+	if err != nil {
+		_ = tmpFile.Close()
+
+		return fmt.Errorf("sync temporary file: %w", err)
+	}
+
+	err = tmpFile.Close()
+	if err != nil {
+		return fmt.Errorf("close temporary file: %w", err)
+	}
+
+	return nil
+}
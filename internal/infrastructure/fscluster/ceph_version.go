@@ -0,0 +1,58 @@
+package fscluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// RecordCephVersion rewrites the cached release of the stored cluster under the write lock.
+// The record is changed without opening its key, so no master key is needed.
+func (r *Repository) RecordCephVersion(ctx context.Context, name, version string) error {
+	err := checkContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if version != "" {
+		_, err = domain.ParseCephVersion(version)
+		if err != nil {
+			return fmt.Errorf("record ceph version: %w", err)
+		}
+	}
+
+	unlock, err := r.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	path := r.clusterFilePath(name)
+
+	record, _, err := readClusterRecord(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return domain.ErrClusterNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	record.CephVersion = version
+
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal cluster file: %w", err)
+	}
+
+	err = writeFileAtomically(path, payload)
+	if err != nil {
+		return fmt.Errorf("write cluster file atomically: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package fscluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+func (r *Repository) writeClusterFile(path string, cluster *domain.Cluster) error {
+	payload, err := r.encodeClusterFile(cluster)
+	if err != nil {
+		return err
+	}
+
+	err = writeFileAtomically(path, payload)
+	if err != nil {
+		return fmt.Errorf("write cluster file atomically: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) encodeClusterFile(cluster *domain.Cluster) ([]byte, error) {
+	record, err := newClusterFile(cluster, r.box)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("marshal cluster file: %w", err)
+	}
+
+	return payload, nil
+}
+
+func (r *Repository) readClusterFile(path string) (*domain.Cluster, error) {
+	record, _, err := readClusterRecord(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := record.toCluster(r.box)
+	if err != nil {
+		return nil, fmt.Errorf("validate cluster file: %w", err)
+	}
+
+	return cluster, nil
+}
+
+// readClusterRecord reads a cluster file upgraded to CurrentSchemaVersion, along with
+// the version it is stored with on disk.
+func readClusterRecord(path string) (clusterFile, int, error) {
+	//nolint:gosec // Path is constructed from repository-owned directory entries.
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return clusterFile{}, 0, fmt.Errorf("read cluster file: %w", err)
+	}
+
+	record, stored, err := migrateRecord(payload)
+	if err != nil {
+		return clusterFile{}, stored, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	return record, stored, nil
+}
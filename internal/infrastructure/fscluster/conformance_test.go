@@ -0,0 +1,23 @@
+package fscluster_test
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/repositorytest"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/fscluster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Conformance(t *testing.T) {
+	t.Parallel()
+
+	repositorytest.Run(t, func(t *testing.T) domain.ClusterRepository {
+		t.Helper()
+
+		repo, err := fscluster.NewRepository(t.TempDir())
+		require.NoError(t, err)
+
+		return repo
+	})
+}
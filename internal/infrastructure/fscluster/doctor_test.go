@@ -0,0 +1,102 @@
+package fscluster_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/fscluster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ListClustersTolerantReportsBrokenFiles(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	repo := newRepositoryWithCluster(t, root, "healthy")
+	writeClusterFile(t, root, "broken", `{"name":`)
+	writeClusterFile(t, root, "invalid", `{"name":"invalid","key":"secret","hosts":[]}`)
+
+	// Act
+	clusters, problems, err := repo.ListClustersTolerant(t.Context())
+	_, strictErr := repo.ListClusters(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+	require.Equal(t, "healthy", clusters[0].Name())
+	require.Len(t, problems, 2)
+	require.Equal(t, filepath.Join(root, "clusters", "broken.json"), problems[0].Source)
+	require.ErrorContains(t, problems[0].Err, "decode cluster file")
+	require.ErrorIs(t, problems[1].Err, domain.ErrEmptyHosts)
+	require.Error(t, strictErr)
+}
+
+func TestRepository_RepairFiles(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	repo := newRepositoryWithCluster(t, root, "healthy")
+	writeClusterFile(t, root, "copy-of-alpha", `{"name":"alpha","key":"secret","hosts":["10.0.0.1:3300"]}`)
+	stale := filepath.Join(root, "clusters", ".tmp-cluster-123")
+	require.NoError(t, os.WriteFile(stale, []byte("{"), 0o600))
+
+	// Act
+	actions, err := repo.RepairFiles(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, actions, 2)
+	require.NoFileExists(t, stale)
+	require.FileExists(t, filepath.Join(root, "clusters", "alpha.json"))
+	require.NoFileExists(t, filepath.Join(root, "clusters", "copy-of-alpha.json"))
+
+	clusters, err := repo.ListClusters(t.Context())
+	require.NoError(t, err)
+	require.Len(t, clusters, 2)
+}
+
+func TestRepository_QuarantineFiles(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	repo := newRepositoryWithCluster(t, root, "healthy")
+	writeClusterFile(t, root, "broken", `not json`)
+	writeClusterFile(t, root, "sealed", `{"name":"sealed","sealedKey":"opaque","hosts":["10.0.0.1:3300"]}`)
+
+	// Act
+	actions, err := repo.QuarantineFiles(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+	require.Contains(t, actions[0], "broken.json")
+	require.NoFileExists(t, filepath.Join(root, "clusters", "broken.json"))
+	require.FileExists(t, filepath.Join(root, "clusters", "sealed.json"))
+
+	quarantined, err := os.ReadDir(filepath.Join(root, "quarantine"))
+	require.NoError(t, err)
+	require.Len(t, quarantined, 1)
+
+	_, problems, err := repo.ListClustersTolerant(t.Context())
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	require.ErrorIs(t, problems[0].Err, fscluster.ErrMasterKeyRequired)
+}
+
+func newRepositoryWithCluster(t *testing.T, root, name string) *fscluster.Repository {
+	t.Helper()
+
+	repo, err := fscluster.NewRepository(root)
+	require.NoError(t, err)
+
+	cluster, err := domain.NewCluster(name, "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateCluster(t.Context(), cluster))
+
+	return repo
+}
@@ -0,0 +1,96 @@
+package fscluster
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const masterKeySize = 32
+
+// Encrypted reports whether new and rewritten cluster keys are sealed.
+func (r *Repository) Encrypted() bool {
+	return r.box != nil
+}
+
+// EncryptKeys rewrites every cluster file that still holds a plaintext key with the key sealed.
+// When neither a passphrase nor master.key is configured, a random master.key is created first.
+// It returns the number of files rewritten.
+func (r *Repository) EncryptKeys(ctx context.Context) (int, error) {
+	err := checkContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	unlock, err := r.lock(true)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	if r.box == nil {
+		err = r.generateMasterKey()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	entries, err := os.ReadDir(r.clustersDir)
+	if err != nil {
+		return 0, fmt.Errorf("read clusters directory: %w", err)
+	}
+
+	encrypted := 0
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(r.clustersDir, entry.Name())
+
+		record, _, err := readClusterRecord(path)
+		if err != nil {
+			return encrypted, err
+		}
+
+		if record.SealedKey != "" {
+			continue
+		}
+
+		cluster, err := record.toCluster(r.box)
+		if err != nil {
+			return encrypted, fmt.Errorf("validate cluster file: %w", err)
+		}
+
+		err = r.writeClusterFile(path, cluster)
+		if err != nil {
+			return encrypted, err
+		}
+
+		encrypted++
+	}
+
+	return encrypted, nil
+}
+
+func (r *Repository) generateMasterKey() error {
+	raw := make([]byte, masterKeySize)
+
+	_, err := rand.Read(raw)
+	if err != nil {
+		return fmt.Errorf("generate master key: %w", err)
+	}
+
+	secret := []byte(base64.StdEncoding.EncodeToString(raw))
+
+	err = createFileAtomically(filepath.Join(r.rootDir, masterKeyFileName), secret)
+	if err != nil {
+		return fmt.Errorf("create master key: %w", err)
+	}
+
+	return r.useMasterSecret(secret)
+}
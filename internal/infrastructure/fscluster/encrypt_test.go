@@ -0,0 +1,133 @@
+package fscluster_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/fscluster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_PassphraseSealsKeys(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	repo, err := fscluster.NewRepository(root, fscluster.WithPassphrase([]byte("hunter2")))
+	require.NoError(t, err)
+
+	cluster, err := domain.NewCluster("cluster-a", "AQBplaintext==", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	// Act
+	err = repo.CreateCluster(t.Context(), cluster)
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, repo.Encrypted())
+
+	payload, err := os.ReadFile(filepath.Join(root, "clusters", "cluster-a.json"))
+	require.NoError(t, err)
+	require.NotContains(t, string(payload), "AQBplaintext")
+	require.Contains(t, string(payload), `"sealedKey"`)
+
+	reopened, err := fscluster.NewRepository(root, fscluster.WithPassphrase([]byte("hunter2")))
+	require.NoError(t, err)
+
+	clusters, err := reopened.ListClusters(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "AQBplaintext==", clusters[0].Key())
+
+	withoutPassphrase, err := fscluster.NewRepository(root)
+	require.NoError(t, err)
+
+	_, err = withoutPassphrase.ListClusters(t.Context())
+	require.ErrorIs(t, err, fscluster.ErrMasterKeyRequired)
+
+	wrongPassphrase, err := fscluster.NewRepository(root, fscluster.WithPassphrase([]byte("hunter3")))
+	require.ErrorIs(t, err, fscluster.ErrWrongMasterKey)
+	require.Nil(t, wrongPassphrase)
+}
+
+func TestRepository_RejectsWrongPassphraseBeforeAnyClusterIsSealed(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	_, err := fscluster.NewRepository(root, fscluster.WithPassphrase([]byte("hunter2")))
+	require.NoError(t, err)
+
+	// Act
+	repo, err := fscluster.NewRepository(root, fscluster.WithPassphrase([]byte("hunter3")))
+
+	// Assert
+	require.ErrorIs(t, err, fscluster.ErrWrongMasterKey)
+	require.Nil(t, repo)
+}
+
+func TestRepository_ChecksLegacyStoresWithoutMasterCheck(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	repo, err := fscluster.NewRepository(root, fscluster.WithPassphrase([]byte("hunter2")))
+	require.NoError(t, err)
+
+	cluster, err := domain.NewCluster("cluster-a", "AQBplaintext==", []string{"10.0.0.1"})
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateCluster(t.Context(), cluster))
+	require.NoError(t, os.Remove(filepath.Join(root, "master.check")))
+
+	// Act
+	wrong, wrongErr := fscluster.NewRepository(root, fscluster.WithPassphrase([]byte("hunter3")))
+	right, rightErr := fscluster.NewRepository(root, fscluster.WithPassphrase([]byte("hunter2")))
+
+	// Assert
+	require.ErrorIs(t, wrongErr, fscluster.ErrWrongMasterKey)
+	require.Nil(t, wrong)
+	require.NoError(t, rightErr)
+	require.True(t, right.Encrypted())
+	require.FileExists(t, filepath.Join(root, "master.check"))
+}
+
+func TestRepository_EncryptKeysMigratesPlaintextFiles(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	repo, err := fscluster.NewRepository(root)
+	require.NoError(t, err)
+	require.False(t, repo.Encrypted())
+
+	for _, name := range []string{"cluster-a", "cluster-b"} {
+		cluster, err := domain.NewCluster(name, "secret-"+name, []string{"10.0.0.1"})
+		require.NoError(t, err)
+		require.NoError(t, repo.CreateCluster(t.Context(), cluster))
+	}
+
+	// Act
+	encrypted, err := repo.EncryptKeys(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, 2, encrypted)
+	require.FileExists(t, filepath.Join(root, "master.key"))
+
+	payload, err := os.ReadFile(filepath.Join(root, "clusters", "cluster-b.json"))
+	require.NoError(t, err)
+	require.NotContains(t, string(payload), "secret-cluster-b")
+
+	reopened, err := fscluster.NewRepository(root)
+	require.NoError(t, err)
+
+	clusters, err := reopened.ListClusters(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "secret-cluster-a", clusters[0].Key())
+	require.Equal(t, "secret-cluster-b", clusters[1].Key())
+
+	again, err := reopened.EncryptKeys(t.Context())
+	require.NoError(t, err)
+	require.Zero(t, again)
+}
@@ -0,0 +1,76 @@
+package fscluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+func (r *Repository) ListClusters(ctx context.Context) ([]*domain.Cluster, error) {
+	clusters, problems, err := r.ListClustersTolerant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(problems) > 0 {
+		return nil, problems[0].Err
+	}
+
+	return clusters, nil
+}
+
+// ListClustersTolerant lists every cluster file that loads and reports the others as problems,
+// sorted by file name. Only failures to read the directory itself are returned as errors.
+func (r *Repository) ListClustersTolerant(ctx context.Context) ([]*domain.Cluster, []domain.LoadProblem, error) {
+	err := checkContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unlock, err := r.lock(false)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+
+	entries, err := os.ReadDir(r.clustersDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read clusters directory: %w", err)
+	}
+
+	clusters := make([]*domain.Cluster, 0, len(entries))
+
+	var problems []domain.LoadProblem
+
+	for _, entry := range entries {
+		err = checkContext(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(r.clustersDir, entry.Name())
+
+		cluster, err := r.readClusterFile(path)
+		if err != nil {
+			problems = append(problems, domain.LoadProblem{Source: path, Err: err})
+
+			continue
+		}
+
+		clusters = append(clusters, cluster)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name() < clusters[j].Name()
+	})
+
+	return clusters, problems, nil
+}
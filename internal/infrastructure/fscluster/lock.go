@@ -0,0 +1,37 @@
+package fscluster
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+const lockFileName = "repository.lock"
+
+// lock takes the advisory repository lock: exclusive for writers, shared for readers.
+// The lock is held on its own file description, so it also serializes goroutines of one process.
+// The returned function releases it.
+func (r *Repository) lock(exclusive bool) (func(), error) {
+	path := filepath.Join(r.rootDir, lockFileName)
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, filePerm) //nolint:gosec // Path is inside the repository root.
+	if err != nil {
+		return nil, fmt.Errorf("open lock file: %w", err)
+	}
+
+	err = lockFile(file, exclusive)
+	if err != nil {
+		_ = file.Close()
+
+		return nil, fmt.Errorf("lock repository: %w", err)
+	}
+
+	return func() {
+		// Closing the file releases the lock.
+		err := file.Close()
+		if err != nil {
+			slog.Warn("unlock repository", "error", err)
+		}
+	}, nil
+}
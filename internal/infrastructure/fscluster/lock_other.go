@@ -0,0 +1,11 @@
+//go:build !unix
+
+package fscluster
+
+import "os"
+
+// lockFile is a no-op where flock is unavailable; the repository then relies on
+// atomic renames alone, as it did before locking was added.
+func lockFile(*os.File, bool) error {
+	return nil
+}
@@ -0,0 +1,158 @@
+package fscluster_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/fscluster"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	helperRootEnv   = "FSCLUSTER_HELPER_ROOT"
+	helperIndexEnv  = "FSCLUSTER_HELPER_INDEX"
+	helperExistCode = 3
+	contenders      = 32
+	processCount    = 8
+)
+
+func TestRepository_ConcurrentCreateFromGoroutines(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+
+	var (
+		created  atomic.Int32
+		existing atomic.Int32
+		wg       sync.WaitGroup
+	)
+
+	// Act
+	for index := range contenders {
+		wg.Go(func() {
+			repo, err := fscluster.NewRepository(root)
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			unique, err := domain.NewCluster(fmt.Sprintf("unique-%02d", index), "secret", []string{"10.0.0.1"})
+			if err == nil {
+				err = repo.CreateCluster(t.Context(), unique)
+			}
+
+			if err != nil {
+				t.Error(err)
+			}
+
+			shared, _ := domain.NewCluster("shared", fmt.Sprintf("secret-%d", index), []string{"10.0.0.1"})
+
+			err = repo.CreateCluster(t.Context(), shared)
+
+			switch {
+			case err == nil:
+				created.Add(1)
+			case errors.Is(err, domain.ErrClusterAlreadyExists):
+				existing.Add(1)
+			default:
+				t.Error(err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	// Assert
+	require.Equal(t, int32(1), created.Load())
+	require.Equal(t, int32(contenders-1), existing.Load())
+	requireClusterCount(t, root, contenders+1)
+}
+
+func TestRepository_ConcurrentCreateFromProcesses(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	commands := make([]*exec.Cmd, 0, processCount)
+
+	for index := range processCount {
+		//nolint:gosec // The test binary re-executes itself as the helper process.
+		command := exec.CommandContext(t.Context(), os.Args[0], "-test.run=^TestRepository_HelperProcess$")
+		command.Env = append(os.Environ(), helperRootEnv+"="+root, fmt.Sprintf("%s=%d", helperIndexEnv, index))
+		commands = append(commands, command)
+	}
+
+	// Act
+	for _, command := range commands {
+		require.NoError(t, command.Start())
+	}
+
+	created := 0
+
+	for _, command := range commands {
+		err := command.Wait()
+
+		var exitErr *exec.ExitError
+
+		switch {
+		case err == nil:
+			created++
+		case errors.As(err, &exitErr) && exitErr.ExitCode() == helperExistCode:
+		default:
+			require.NoError(t, err)
+		}
+	}
+
+	// Assert
+	require.Equal(t, 1, created)
+	requireClusterCount(t, root, processCount+1)
+}
+
+// TestRepository_HelperProcess is the body of each process started by
+// TestRepository_ConcurrentCreateFromProcesses; it does nothing in a normal test run.
+func TestRepository_HelperProcess(t *testing.T) {
+	t.Parallel()
+
+	root := os.Getenv(helperRootEnv)
+	if root == "" {
+		return
+	}
+
+	repo, err := fscluster.NewRepository(root)
+	require.NoError(t, err)
+
+	index := os.Getenv(helperIndexEnv)
+
+	unique, err := domain.NewCluster("process-"+index, "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateCluster(t.Context(), unique))
+
+	shared, err := domain.NewCluster("shared", "secret-"+index, []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	err = repo.CreateCluster(t.Context(), shared)
+	if errors.Is(err, domain.ErrClusterAlreadyExists) {
+		os.Exit(helperExistCode) //nolint:revive // The exit code reports the outcome to the parent test.
+	}
+
+	require.NoError(t, err)
+}
+
+func requireClusterCount(t *testing.T, root string, want int) {
+	t.Helper()
+
+	repo, err := fscluster.NewRepository(root)
+	require.NoError(t, err)
+
+	clusters, err := repo.ListClusters(t.Context())
+	require.NoError(t, err)
+	require.Len(t, clusters, want)
+}
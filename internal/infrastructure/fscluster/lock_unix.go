@@ -0,0 +1,23 @@
+//go:build unix
+
+package fscluster
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+func lockFile(file *os.File, exclusive bool) error {
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+
+	for {
+		err := syscall.Flock(int(file.Fd()), how) //nolint:gosec // File descriptors fit in int.
+		if !errors.Is(err, syscall.EINTR) {
+			return err //nolint:wrapcheck // The caller wraps lock errors.
+		}
+	}
+}
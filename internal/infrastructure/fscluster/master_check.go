@@ -0,0 +1,87 @@
+package fscluster
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/secretbox"
+)
+
+const (
+	masterCheckFileName  = "master.check"
+	masterCheckPlaintext = "cephdoctor master key check"
+)
+
+var ErrWrongMasterKey = errors.New("wrong passphrase or master.key for this repository")
+
+// verifyMasterKey opens <root>/master.check with box so a wrong passphrase fails up front
+// instead of sealing new clusters under a second master key.
+func (r *Repository) verifyMasterKey(box *secretbox.Box) error {
+	path := filepath.Join(r.rootDir, masterCheckFileName)
+
+	sealed, err := os.ReadFile(path) //nolint:gosec // Path is inside the repository root.
+	if errors.Is(err, os.ErrNotExist) {
+		return r.createMasterCheck(box, path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("read master check: %w", err)
+	}
+
+	plaintext, err := box.Open(string(sealed))
+	if err != nil || plaintext != masterCheckPlaintext {
+		return ErrWrongMasterKey
+	}
+
+	return nil
+}
+
+// createMasterCheck writes master.check on first use. Stores sealed before master.check existed
+// are checked against an existing sealed key first so the first passphrase used is not trusted blindly.
+func (r *Repository) createMasterCheck(box *secretbox.Box, path string) error {
+	err := r.openAnySealedKey(box)
+	if err != nil {
+		return err
+	}
+
+	sealed, err := box.Seal(masterCheckPlaintext)
+	if err != nil {
+		return fmt.Errorf("seal master check: %w", err)
+	}
+
+	err = createFileAtomically(path, []byte(sealed))
+	if errors.Is(err, os.ErrExist) {
+		return r.verifyMasterKey(box)
+	}
+
+	if err != nil {
+		return fmt.Errorf("create master check: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) openAnySealedKey(box *secretbox.Box) error {
+	paths, err := filepath.Glob(filepath.Join(r.clustersDir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("list cluster files: %w", err)
+	}
+
+	for _, path := range paths {
+		record, _, err := readClusterRecord(path)
+		if err != nil || record.SealedKey == "" {
+			continue
+		}
+
+		_, err = box.Open(record.SealedKey)
+		if err != nil {
+			return ErrWrongMasterKey
+		}
+
+		return nil
+	}
+
+	return nil
+}
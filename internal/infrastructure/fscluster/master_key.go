@@ -0,0 +1,96 @@
+package fscluster
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/secretbox"
+)
+
+const (
+	masterKeyFileName  = "master.key"
+	masterSaltFileName = "master.salt"
+)
+
+var ErrMasterKeyRequired = errors.New("cluster key is encrypted but no passphrase or master.key is available")
+
+// WithPassphrase derives the master key from passphrase instead of <root>/master.key.
+func WithPassphrase(passphrase []byte) Option {
+	return func(r *Repository) {
+		r.passphrase = passphrase
+	}
+}
+
+// loadMasterKey enables sealing when a passphrase was given or <root>/master.key exists.
+// Without either, keys are stored in plaintext as before.
+func (r *Repository) loadMasterKey() error {
+	secret := r.passphrase
+	if len(secret) == 0 {
+		content, err := os.ReadFile(filepath.Join(r.rootDir, masterKeyFileName))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("read master key: %w", err)
+		}
+
+		secret = content
+	}
+
+	return r.useMasterSecret(secret)
+}
+
+func (r *Repository) useMasterSecret(secret []byte) error {
+	salt, err := r.loadSalt()
+	if err != nil {
+		return err
+	}
+
+	box, err := secretbox.New(secret, salt)
+	if err != nil {
+		return fmt.Errorf("derive master key: %w", err)
+	}
+
+	err = r.verifyMasterKey(box)
+	if err != nil {
+		return err
+	}
+
+	r.box = box
+
+	return nil
+}
+
+// loadSalt reads <root>/master.salt, creating it on first use. A salt created
+// concurrently by another process wins so every process derives the same key.
+func (r *Repository) loadSalt() ([]byte, error) {
+	path := filepath.Join(r.rootDir, masterSaltFileName)
+
+	salt, err := os.ReadFile(path) //nolint:gosec // Path is inside the repository root.
+	if err == nil {
+		return salt, nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read master salt: %w", err)
+	}
+
+	salt, err = secretbox.NewSalt()
+	if err != nil {
+		return nil, fmt.Errorf("new master salt: %w", err)
+	}
+
+	err = createFileAtomically(path, salt)
+	if errors.Is(err, os.ErrExist) {
+		return r.loadSalt()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("create master salt: %w", err)
+	}
+
+	return salt, nil
+}
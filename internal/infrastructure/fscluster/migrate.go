@@ -0,0 +1,70 @@
+package fscluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// MigrateFiles rewrites every cluster file stored with an older schema version in the
+// current layout and returns how many it rewrote. Sealed keys are carried over as they are,
+// so no master key is needed. Files that cannot be decoded are skipped and reported as
+// problems, like ListClustersTolerant does, so one broken file does not hold back the rest.
+func (r *Repository) MigrateFiles(ctx context.Context) (int, []domain.LoadProblem, error) {
+	err := checkContext(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	unlock, err := r.lock(true)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer unlock()
+
+	entries, err := os.ReadDir(r.clustersDir)
+	if err != nil {
+		return 0, nil, fmt.Errorf("read clusters directory: %w", err)
+	}
+
+	migrated := 0
+
+	var problems []domain.LoadProblem
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(r.clustersDir, entry.Name())
+
+		record, stored, err := readClusterRecord(path)
+		if err != nil {
+			problems = append(problems, domain.LoadProblem{Source: path, Err: err})
+
+			continue
+		}
+
+		if stored == CurrentSchemaVersion {
+			continue
+		}
+
+		payload, err := json.Marshal(record)
+		if err != nil {
+			return migrated, problems, fmt.Errorf("marshal cluster file: %w", err)
+		}
+
+		err = writeFileAtomically(path, payload)
+		if err != nil {
+			return migrated, problems, fmt.Errorf("write cluster file atomically: %w", err)
+		}
+
+		migrated++
+	}
+
+	return migrated, problems, nil
+}
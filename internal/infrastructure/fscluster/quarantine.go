@@ -0,0 +1,78 @@
+package fscluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/secretbox"
+)
+
+const (
+	quarantineDirName    = "quarantine"
+	quarantineTimeFormat = "20060102T150405Z"
+)
+
+// QuarantineFiles moves every cluster file that is corrupt into <root>/quarantine, so listings
+// succeed again while the original bytes stay available, and describes each move. Files that
+// only lack the master key or come from a newer cephdoctor are not corrupt and stay in place.
+func (r *Repository) QuarantineFiles(ctx context.Context) ([]string, error) {
+	err := checkContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := r.lock(true)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	entries, err := os.ReadDir(r.clustersDir)
+	if err != nil {
+		return nil, fmt.Errorf("read clusters directory: %w", err)
+	}
+
+	quarantineDir := filepath.Join(r.rootDir, quarantineDirName)
+	suffix := "." + time.Now().UTC().Format(quarantineTimeFormat)
+
+	var actions []string
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(r.clustersDir, entry.Name())
+
+		_, loadErr := r.readClusterFile(path)
+		if loadErr == nil || !isCorrupt(loadErr) {
+			continue
+		}
+
+		err = os.MkdirAll(quarantineDir, dirPerm)
+		if err != nil {
+			return actions, fmt.Errorf("create quarantine directory: %w", err)
+		}
+
+		target := filepath.Join(quarantineDir, entry.Name()+suffix)
+
+		err = os.Rename(path, target)
+		if err != nil {
+			return actions, fmt.Errorf("quarantine cluster file: %w", err)
+		}
+
+		actions = append(actions, fmt.Sprintf("quarantined %s to %s: %v", path, target, loadErr))
+	}
+
+	return actions, nil
+}
+
+func isCorrupt(err error) bool {
+	return !errors.Is(err, ErrMasterKeyRequired) &&
+		!errors.Is(err, secretbox.ErrDecrypt) &&
+		!errors.Is(err, ErrNewerSchemaVersion)
+}
@@ -0,0 +1,91 @@
+package fscluster
+
+import (
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/secretbox"
+)
+
+// clusterFile is the JSON layout of a stored cluster.
+// Key holds a plaintext key; SealedKey replaces it once keys are encrypted at rest.
+type clusterFile struct {
+	SchemaVersion int      `json:"schemaVersion"`
+	Name          string   `json:"name"`
+	Key           string   `json:"key,omitempty"`
+	SealedKey     string   `json:"sealedKey,omitempty"`
+	Hosts         []string `json:"hosts"`
+	Entity        string   `json:"entity,omitempty"`
+	Backend       string   `json:"backend,omitempty"`
+	SSH           string   `json:"ssh,omitempty"`
+
+	Labels      map[string]string `json:"labels,omitempty"`
+	CephVersion string            `json:"cephVersion,omitempty"`
+}
+
+// newClusterFile builds the record for cluster, sealing its key when box is not nil.
+func newClusterFile(cluster *domain.Cluster, box *secretbox.Box) (clusterFile, error) {
+	record := clusterFile{
+		SchemaVersion: CurrentSchemaVersion,
+		Name:          cluster.Name(),
+		Key:           cluster.Key(),
+		SealedKey:     "",
+		Hosts:         cluster.Hosts(),
+		Entity:        cluster.Entity(),
+		Backend:       string(cluster.Backend()),
+		SSH:           "",
+		Labels:        cluster.Labels(),
+		CephVersion:   cluster.CephVersion(),
+	}
+
+	if target := cluster.SSHTarget(); target != nil {
+		record.SSH = target.String()
+	}
+
+	if box != nil {
+		sealed, err := box.Seal(record.Key)
+		if err != nil {
+			return clusterFile{}, fmt.Errorf("seal cluster key: %w", err)
+		}
+
+		record.Key, record.SealedKey = "", sealed
+	}
+
+	return record, nil
+}
+
+func (r clusterFile) toCluster(box *secretbox.Box) (*domain.Cluster, error) {
+	key := r.Key
+
+	if r.SealedKey != "" {
+		if box == nil {
+			return nil, ErrMasterKeyRequired
+		}
+
+		opened, err := box.Open(r.SealedKey)
+		if err != nil {
+			return nil, fmt.Errorf("open cluster key: %w", err)
+		}
+
+		key = opened
+	}
+
+	opts := []domain.ClusterOption{
+		domain.WithBackend(domain.Backend(r.Backend)), domain.WithLabels(r.Labels), domain.WithCephVersion(r.CephVersion),
+	}
+
+	if r.Entity != "" {
+		opts = append(opts, domain.WithEntity(r.Entity))
+	}
+
+	if r.SSH != "" {
+		target, err := domain.ParseSSHTarget(r.SSH)
+		if err != nil {
+			return nil, fmt.Errorf("parse ssh target: %w", err)
+		}
+
+		opts = append(opts, domain.WithSSHTarget(target))
+	}
+
+	return domain.NewCluster(r.Name, key, r.Hosts, opts...) //nolint:wrapcheck // Caller wraps validation errors.
+}
@@ -0,0 +1,90 @@
+package fscluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// RenameCluster stores cluster under its new name and removes the old record.
+// The new file is linked into place so an existing cluster is never overwritten.
+func (r *Repository) RenameCluster(ctx context.Context, oldName string, cluster *domain.Cluster) error {
+	err := checkContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cluster == nil {
+		return errNilCluster
+	}
+
+	if oldName == cluster.Name() {
+		return r.UpdateCluster(ctx, cluster)
+	}
+
+	unlock, err := r.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	oldFilePath := r.clusterFilePath(oldName)
+
+	_, statErr := os.Stat(oldFilePath)
+	if errors.Is(statErr, os.ErrNotExist) {
+		return domain.ErrClusterNotFound
+	}
+
+	if statErr != nil {
+		return fmt.Errorf("check cluster file existence: %w", statErr)
+	}
+
+	payload, err := r.encodeClusterFile(cluster)
+	if err != nil {
+		return err
+	}
+
+	err = createFileAtomically(r.clusterFilePath(cluster.Name()), payload)
+	if errors.Is(err, os.ErrExist) {
+		return domain.ErrClusterAlreadyExists
+	}
+
+	if err != nil {
+		return fmt.Errorf("create cluster file atomically: %w", err)
+	}
+
+	err = os.Remove(oldFilePath)
+	if err != nil {
+		return fmt.Errorf("remove renamed cluster file: %w", err)
+	}
+
+	return nil
+}
+
+// createFileAtomically publishes a fully written file at path, failing with os.ErrExist
+// instead of replacing a file that is already there.
+func createFileAtomically(path string, payload []byte) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), tempFilePrefix+"*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+
+	tmpFilePath := tmpFile.Name()
+	defer os.Remove(tmpFilePath) //nolint:errcheck // The temporary name is always removed after linking.
+
+	err = writeAndCloseTemporaryFile(tmpFile, payload)
+	if err != nil {
+		return err
+	}
+
+	err = os.Link(tmpFilePath, path)
+	if err != nil {
+		return fmt.Errorf("link temporary file: %w", err)
+	}
+
+	return nil
+}
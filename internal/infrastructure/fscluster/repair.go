@@ -0,0 +1,98 @@
+package fscluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const tempFilePrefix = ".tmp-cluster-"
+
+// RepairFiles fixes what can be fixed without guessing and describes each fix. Temporary files
+// left by interrupted writes are removed, and records stored under a file name that does not
+// match their cluster name are moved to the right one. Anything else is left for QuarantineFiles.
+func (r *Repository) RepairFiles(ctx context.Context) ([]string, error) {
+	err := checkContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := r.lock(true)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	entries, err := os.ReadDir(r.clustersDir)
+	if err != nil {
+		return nil, fmt.Errorf("read clusters directory: %w", err)
+	}
+
+	var actions []string
+
+	for _, entry := range entries {
+		path := filepath.Join(r.clustersDir, entry.Name())
+
+		action, err := r.repairEntry(path, entry)
+		if err != nil {
+			return actions, err
+		}
+
+		if action != "" {
+			actions = append(actions, action)
+		}
+	}
+
+	return actions, nil
+}
+
+func (r *Repository) repairEntry(path string, entry os.DirEntry) (string, error) {
+	// Writers create temporary files only while holding the lock, so any left now are stale.
+	if !entry.IsDir() && strings.HasPrefix(entry.Name(), tempFilePrefix) {
+		err := os.Remove(path)
+		if err != nil {
+			return "", fmt.Errorf("remove stale temporary file: %w", err)
+		}
+
+		return "removed stale temporary file " + path, nil
+	}
+
+	if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+		return "", nil
+	}
+
+	record, _, err := readClusterRecord(path)
+	if err != nil || record.Name == "" {
+		return "", nil //nolint:nilerr // Unreadable records are not repairable and are left for quarantine.
+	}
+
+	target := r.clusterFilePath(record.Name)
+	if target == path {
+		return "", nil
+	}
+
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return "", fmt.Errorf("marshal cluster file: %w", err)
+	}
+
+	err = createFileAtomically(target, payload)
+	if errors.Is(err, os.ErrExist) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("create cluster file atomically: %w", err)
+	}
+
+	err = os.Remove(path)
+	if err != nil {
+		return "", fmt.Errorf("remove misnamed cluster file: %w", err)
+	}
+
+	return fmt.Sprintf("moved %s to %s", path, target), nil
+}
@@ -126,6 +126,31 @@ func TestRepository_UpdateCluster(t *testing.T) {
 	require.Equal(t, []string{"10.0.0.2:3300"}, clusters[0].Hosts())
 }
 
+func TestRepository_PersistsBackendAndSSHTarget(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	repo, err := fscluster.NewRepository(t.TempDir())
+	require.NoError(t, err)
+
+	target, err := domain.ParseSSHTarget("ceph@admin-1:2222")
+	require.NoError(t, err)
+
+	cluster, err := domain.NewCluster("cluster-a", "secret", []string{"10.0.0.1"},
+		domain.WithBackend(domain.BackendSSH), domain.WithSSHTarget(target))
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, repo.CreateCluster(t.Context(), cluster))
+
+	// Assert
+	clusters, err := repo.ListClusters(t.Context())
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+	require.Equal(t, domain.BackendSSH, clusters[0].Backend())
+	require.Equal(t, "ceph@admin-1:2222", clusters[0].SSHTarget().String())
+}
+
 func TestRepository_UpdateCluster_NotFound(t *testing.T) {
 	t.Parallel()
 
@@ -298,3 +323,81 @@ func TestRepository_RespectsCanceledContext(t *testing.T) {
 	require.Error(t, createErr)
 	require.ErrorIs(t, createErr, context.Canceled)
 }
+
+func TestRepository_RenameCluster(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	repo, err := fscluster.NewRepository(root)
+	require.NoError(t, err)
+
+	original, err := domain.NewCluster("cluster-a", "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateCluster(t.Context(), original))
+
+	renamed, err := original.Edited("cluster-b", "rotated", original.Hosts())
+	require.NoError(t, err)
+
+	// Act
+	err = repo.RenameCluster(t.Context(), "cluster-a", renamed)
+
+	// Assert
+	require.NoError(t, err)
+
+	clusters, err := repo.ListClusters(t.Context())
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+	require.Equal(t, "cluster-b", clusters[0].Name())
+	require.Equal(t, "rotated", clusters[0].Key())
+
+	entries, err := os.ReadDir(filepath.Join(root, "clusters"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestRepository_RenameCluster_TargetExists(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	repo, err := fscluster.NewRepository(t.TempDir())
+	require.NoError(t, err)
+
+	alpha, err := domain.NewCluster("alpha", "secret-a", []string{"10.0.0.1"})
+	require.NoError(t, err)
+	zeta, err := domain.NewCluster("zeta", "secret-z", []string{"10.0.0.2"})
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateCluster(t.Context(), alpha))
+	require.NoError(t, repo.CreateCluster(t.Context(), zeta))
+
+	renamed, err := alpha.Edited("zeta", alpha.Key(), alpha.Hosts())
+	require.NoError(t, err)
+
+	// Act
+	err = repo.RenameCluster(t.Context(), "alpha", renamed)
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrClusterAlreadyExists)
+
+	clusters, err := repo.ListClusters(t.Context())
+	require.NoError(t, err)
+	require.Len(t, clusters, 2)
+	require.Equal(t, "secret-z", clusters[1].Key())
+}
+
+func TestRepository_RenameCluster_NotFound(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	repo, err := fscluster.NewRepository(t.TempDir())
+	require.NoError(t, err)
+
+	cluster, err := domain.NewCluster("cluster-b", "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	// Act
+	err = repo.RenameCluster(t.Context(), "cluster-a", cluster)
+
+	// Assert
+	require.ErrorIs(t, err, domain.ErrClusterNotFound)
+}
@@ -0,0 +1,78 @@
+package fscluster
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// CurrentSchemaVersion is the cluster file layout written by this build.
+// Version 1 files predate the schemaVersion field and have no entity; version 3 adds labels and
+// version 4 the cached ceph release.
+const (
+	CurrentSchemaVersion = 4
+	legacySchemaVersion  = 1
+	schemaVersionField   = "schemaVersion"
+)
+
+var (
+	ErrNewerSchemaVersion   = errors.New("cluster file was written by a newer cephdoctor")
+	ErrInvalidSchemaVersion = errors.New("invalid cluster file schema version")
+)
+
+// migrateRecord decodes payload and upgrades it to CurrentSchemaVersion.
+// It reports the version the payload was stored with.
+func migrateRecord(payload []byte) (clusterFile, int, error) {
+	var fields map[string]json.RawMessage
+
+	err := json.Unmarshal(payload, &fields)
+	if err != nil {
+		return clusterFile{}, 0, fmt.Errorf("decode cluster file: %w", err)
+	}
+
+	stored := legacySchemaVersion
+
+	if raw, ok := fields[schemaVersionField]; ok {
+		err = json.Unmarshal(raw, &stored)
+		if err != nil {
+			return clusterFile{}, 0, fmt.Errorf("decode schema version: %w", err)
+		}
+	}
+
+	if stored < legacySchemaVersion {
+		return clusterFile{}, stored, fmt.Errorf("%w: %d", ErrInvalidSchemaVersion, stored)
+	}
+
+	if stored > CurrentSchemaVersion {
+		return clusterFile{}, stored, fmt.Errorf("%w: schema version %d, this build supports up to %d",
+			ErrNewerSchemaVersion, stored, CurrentSchemaVersion)
+	}
+
+	for version := stored; version < CurrentSchemaVersion; version++ {
+		step, ok := migrations[version]
+		if !ok {
+			return clusterFile{}, stored, fmt.Errorf("%w: no migration from version %d", ErrInvalidSchemaVersion, version)
+		}
+
+		err = step(fields)
+		if err != nil {
+			return clusterFile{}, stored, fmt.Errorf("migrate schema version %d: %w", version, err)
+		}
+	}
+
+	fields[schemaVersionField] = json.RawMessage(fmt.Sprint(CurrentSchemaVersion))
+
+	migrated, err := json.Marshal(fields)
+	if err != nil {
+		return clusterFile{}, stored, fmt.Errorf("encode migrated cluster file: %w", err)
+	}
+
+	var record clusterFile
+
+	err = json.Unmarshal(migrated, &record)
+	if err != nil {
+		return clusterFile{}, stored, fmt.Errorf("decode cluster file: %w", err)
+	}
+
+	return record, stored, nil
+}
@@ -0,0 +1,32 @@
+package fscluster
+
+import "encoding/json"
+
+// migration upgrades a decoded cluster file by exactly one schema version.
+type migration func(fields map[string]json.RawMessage) error
+
+// migrations maps each schema version to the step that upgrades it to the next one.
+//
+//nolint:gochecknoglobals // The registry is a fixed table of upgrade steps.
+var migrations = map[int]migration{
+	1: migrateEntityDefault,
+	2: migrateOptionalFieldAdded,
+	3: migrateOptionalFieldAdded,
+}
+
+// migrateEntityDefault records the entity version 1 files implicitly authenticated as.
+// The value is spelled out so later changes to the default cannot alter old files.
+func migrateEntityDefault(fields map[string]json.RawMessage) error {
+	if _, ok := fields["entity"]; !ok {
+		fields["entity"] = json.RawMessage(`"client.admin"`)
+	}
+
+	return nil
+}
+
+// migrateOptionalFieldAdded upgrades to a version that may carry a new optional field, such as
+// labels or the ceph release. Files without the field leave it unset, so nothing changes; the bump
+// keeps older builds from dropping the field on rewrite.
+func migrateOptionalFieldAdded(map[string]json.RawMessage) error {
+	return nil
+}
@@ -0,0 +1,145 @@
+package fscluster_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/fscluster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ReadsLegacyFiles(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	repo, err := fscluster.NewRepository(root)
+	require.NoError(t, err)
+	writeClusterFile(t, root, "legacy", `{"name":"legacy","key":"secret","hosts":["10.0.0.1:3300"]}`)
+
+	// Act
+	clusters, err := repo.ListClusters(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+	require.Equal(t, domain.DefaultEntity, clusters[0].Entity())
+	require.Equal(t, "secret", clusters[0].Key())
+}
+
+func TestRepository_RefusesNewerSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	repo, err := fscluster.NewRepository(root)
+	require.NoError(t, err)
+	writeClusterFile(t, root, "future",
+		`{"schemaVersion":99,"name":"future","key":"secret","hosts":["10.0.0.1:3300"],"tags":["x"]}`)
+
+	// Act
+	_, listErr := repo.ListClusters(t.Context())
+	_, problems, migrateErr := repo.MigrateFiles(t.Context())
+
+	// Assert
+	require.ErrorIs(t, listErr, fscluster.ErrNewerSchemaVersion)
+	require.ErrorContains(t, listErr, "future.json")
+	require.NoError(t, migrateErr)
+	require.Len(t, problems, 1)
+	require.ErrorIs(t, problems[0].Err, fscluster.ErrNewerSchemaVersion)
+}
+
+func TestRepository_MigrateFilesSkipsUnreadableFiles(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	repo, err := fscluster.NewRepository(root)
+	require.NoError(t, err)
+	writeClusterFile(t, root, "broken", `{`)
+	writeClusterFile(t, root, "legacy", `{"name":"legacy","key":"secret","hosts":["10.0.0.1:3300"]}`)
+
+	// Act
+	migrated, problems, err := repo.MigrateFiles(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, 1, migrated)
+	require.Len(t, problems, 1)
+	require.Equal(t, filepath.Join(root, "clusters", "broken.json"), problems[0].Source)
+
+	payload, err := os.ReadFile(filepath.Join(root, "clusters", "legacy.json"))
+	require.NoError(t, err)
+	require.Contains(t, string(payload), `"schemaVersion":4`)
+}
+
+func TestRepository_RejectsInvalidSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	repo := newRepositoryWithCluster(t, root, "healthy")
+	writeClusterFile(t, root, "zero", `{"schemaVersion":0,"name":"zero","key":"secret","hosts":["10.0.0.1:3300"]}`)
+	writeClusterFile(t, root, "negative",
+		`{"schemaVersion":-1,"name":"negative","key":"secret","hosts":["10.0.0.1:3300"]}`)
+
+	// Act
+	_, strictErr := repo.ListClusters(t.Context())
+	clusters, problems, tolerantErr := repo.ListClustersTolerant(t.Context())
+
+	// Assert
+	require.ErrorIs(t, strictErr, fscluster.ErrInvalidSchemaVersion)
+	require.NoError(t, tolerantErr)
+	require.Len(t, clusters, 1)
+	require.Len(t, problems, 2)
+	require.ErrorIs(t, problems[0].Err, fscluster.ErrInvalidSchemaVersion)
+	require.ErrorIs(t, problems[1].Err, fscluster.ErrInvalidSchemaVersion)
+}
+
+func TestRepository_MigrateFilesRewritesOlderVersions(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	root := t.TempDir()
+	repo, err := fscluster.NewRepository(root)
+	require.NoError(t, err)
+	writeClusterFile(t, root, "sealed",
+		`{"name":"sealed","sealedKey":"opaque","hosts":["10.0.0.1:3300"],"backend":"ssh","ssh":"ceph@admin:22"}`)
+
+	current, err := domain.NewCluster("current", "secret", []string{"10.0.0.2"})
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateCluster(t.Context(), current))
+
+	// Act
+	migrated, problems, err := repo.MigrateFiles(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, 1, migrated)
+	require.Empty(t, problems)
+
+	payload, err := os.ReadFile(filepath.Join(root, "clusters", "sealed.json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"schemaVersion": 4,
+		"name": "sealed",
+		"sealedKey": "opaque",
+		"hosts": ["10.0.0.1:3300"],
+		"entity": "client.admin",
+		"backend": "ssh",
+		"ssh": "ceph@admin:22"
+	}`, string(payload))
+
+	again, _, err := repo.MigrateFiles(t.Context())
+	require.NoError(t, err)
+	require.Zero(t, again)
+}
+
+func writeClusterFile(t *testing.T, root, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(root, "clusters", name+".json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
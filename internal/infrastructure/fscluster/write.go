@@ -0,0 +1,94 @@
+package fscluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+func (r *Repository) CreateCluster(ctx context.Context, cluster *domain.Cluster) error {
+	err := checkContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cluster == nil {
+		return errNilCluster
+	}
+
+	unlock, err := r.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	targetFilePath := r.clusterFilePath(cluster.Name())
+
+	_, statErr := os.Stat(targetFilePath)
+	if statErr == nil {
+		return domain.ErrClusterAlreadyExists
+	}
+
+	if !errors.Is(statErr, os.ErrNotExist) {
+		return fmt.Errorf("check cluster file existence: %w", statErr)
+	}
+
+	return r.writeClusterFile(targetFilePath, cluster)
+}
+
+func (r *Repository) UpdateCluster(ctx context.Context, cluster *domain.Cluster) error {
+	err := checkContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cluster == nil {
+		return errNilCluster
+	}
+
+	unlock, err := r.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	targetFilePath := r.clusterFilePath(cluster.Name())
+
+	_, statErr := os.Stat(targetFilePath)
+	if errors.Is(statErr, os.ErrNotExist) {
+		return domain.ErrClusterNotFound
+	}
+
+	if statErr != nil {
+		return fmt.Errorf("check cluster file existence: %w", statErr)
+	}
+
+	return r.writeClusterFile(targetFilePath, cluster)
+}
+
+func (r *Repository) DeleteCluster(ctx context.Context, name string) error {
+	err := checkContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := r.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	targetFilePath := r.clusterFilePath(name)
+
+	err = os.Remove(targetFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return domain.ErrClusterNotFound
+	} else if err != nil {
+		return fmt.Errorf("remove cluster file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// Package secretbox encrypts small secrets with a key derived from a passphrase.
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	SaltSize = 16
+	keySize  = 32
+	scryptN  = 1 << 15
+	scryptR  = 8
+	scryptP  = 1
+)
+
+var ErrDecrypt = errors.New("decrypt secret: wrong passphrase or corrupted data")
+
+// Box seals secrets with AES-256-GCM under a scrypt-derived key.
+type Box struct {
+	aead cipher.AEAD
+}
+
+// NewSalt returns a random salt for New.
+func NewSalt() ([]byte, error) {
+	salt := make([]byte, SaltSize)
+
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+
+	return salt, nil
+}
+
+// New derives the key for passphrase and salt. The same pair always opens what it sealed.
+func New(passphrase, salt []byte) (*Box, error) {
+	key, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create gcm: %w", err)
+	}
+
+	return &Box{aead: aead}, nil
+}
+
+// Seal encrypts plaintext under a fresh nonce and returns base64 of nonce and ciphertext.
+func (b *Box) Seal(plaintext string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+
+	sealed := b.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open reverses Seal.
+func (b *Box) Open(sealed string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(raw) < b.aead.NonceSize() {
+		return "", ErrDecrypt
+	}
+
+	nonce, ciphertext := raw[:b.aead.NonceSize()], raw[b.aead.NonceSize():]
+
+	plaintext, err := b.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrDecrypt
+	}
+
+	return string(plaintext), nil
+}
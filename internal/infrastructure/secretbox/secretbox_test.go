@@ -0,0 +1,53 @@
+package secretbox_test
+
+import (
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/secretbox"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBox_SealAndOpen(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	salt, err := secretbox.NewSalt()
+	require.NoError(t, err)
+
+	box, err := secretbox.New([]byte("correct horse"), salt)
+	require.NoError(t, err)
+
+	// Act
+	sealed, err := box.Seal("AQBsecret==")
+	require.NoError(t, err)
+
+	opened, err := box.Open(sealed)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "AQBsecret==", opened)
+	require.NotContains(t, sealed, "AQBsecret")
+}
+
+func TestBox_OpenWithWrongPassphrase(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	salt, err := secretbox.NewSalt()
+	require.NoError(t, err)
+
+	box, err := secretbox.New([]byte("correct horse"), salt)
+	require.NoError(t, err)
+
+	sealed, err := box.Seal("AQBsecret==")
+	require.NoError(t, err)
+
+	other, err := secretbox.New([]byte("battery staple"), salt)
+	require.NoError(t, err)
+
+	// Act
+	_, err = other.Open(sealed)
+
+	// Assert
+	require.ErrorIs(t, err, secretbox.ErrDecrypt)
+}
@@ -0,0 +1,27 @@
+package secretref
+
+import (
+	"context"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// CephClient resolves the cluster key right before handing the cluster to next,
+// so resolved keys are never stored or passed to other callers.
+type CephClient struct {
+	next     domain.CephClient
+	resolver domain.KeyResolver
+}
+
+func NewCephClient(next domain.CephClient, resolver domain.KeyResolver) *CephClient {
+	return &CephClient{next: next, resolver: resolver}
+}
+
+func (c *CephClient) Status(ctx context.Context, cluster *domain.Cluster) (*domain.CephStatus, error) {
+	resolved, err := c.resolver.ResolveKey(ctx, cluster)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Resolver errors already name the cluster and reference.
+	}
+
+	return c.next.Status(ctx, resolved) //nolint:wrapcheck // The decorator is transparent to callers.
+}
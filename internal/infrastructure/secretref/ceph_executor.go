@@ -0,0 +1,32 @@
+package secretref
+
+import (
+	"context"
+	"io"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// CephExecutor resolves the cluster key right before handing the cluster to next, like CephClient.
+type CephExecutor struct {
+	next     domain.CephExecutor
+	resolver domain.KeyResolver
+}
+
+func NewCephExecutor(next domain.CephExecutor, resolver domain.KeyResolver) *CephExecutor {
+	return &CephExecutor{next: next, resolver: resolver}
+}
+
+func (e *CephExecutor) Exec(
+	ctx context.Context,
+	cluster *domain.Cluster,
+	command domain.CephCommand,
+	stdout, stderr io.Writer,
+) (int, error) {
+	resolved, err := e.resolver.ResolveKey(ctx, cluster)
+	if err != nil {
+		return 0, err //nolint:wrapcheck // Resolver errors already name the cluster and reference.
+	}
+
+	return e.next.Exec(ctx, resolved, command, stdout, stderr) //nolint:wrapcheck // The decorator is transparent.
+}
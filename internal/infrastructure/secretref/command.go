@@ -0,0 +1,44 @@
+package secretref
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	execTimeout   = 30 * time.Second
+	maxStderrSize = 200
+)
+
+// runCommand runs the command without a shell and returns its trimmed stdout.
+// Only stderr is quoted in errors, since stdout is the secret.
+func runCommand(ctx context.Context, commandLine string) (string, error) {
+	fields := strings.Fields(commandLine)
+
+	runCtx, cancel := context.WithTimeout(ctx, execTimeout)
+	defer cancel()
+
+	//nolint:gosec // The command is configured by the operator as the key source.
+	command := exec.CommandContext(runCtx, fields[0], fields[1:]...)
+
+	var stdout, stderr bytes.Buffer
+
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	err := command.Run()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if len(message) > maxStderrSize {
+			message = message[:maxStderrSize] + "..."
+		}
+
+		return "", fmt.Errorf("run key command: %w: %s", err, message)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
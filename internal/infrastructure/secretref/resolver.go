@@ -0,0 +1,68 @@
+// Package secretref resolves cluster key references such as env:NAME, file:PATH and exec:COMMAND.
+package secretref
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+var (
+	ErrEnvNotSet   = errors.New("environment variable is not set")
+	ErrEmptySecret = errors.New("secret resolved to an empty key")
+)
+
+// Resolver reads referenced keys when they are needed and never caches them.
+type Resolver struct{}
+
+func NewResolver() *Resolver {
+	return &Resolver{}
+}
+
+func (r *Resolver) ResolveKey(ctx context.Context, cluster *domain.Cluster) (*domain.Cluster, error) {
+	scheme, target, ok := domain.ParseKeyReference(cluster.Key())
+	if !ok {
+		return cluster, nil
+	}
+
+	key, err := resolve(ctx, scheme, target)
+	if err == nil && key == "" {
+		err = ErrEmptySecret
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("resolve key %s for cluster %s: %w", cluster.Key(), cluster.Name(), err)
+	}
+
+	resolved, err := cluster.Edited(cluster.Name(), key, cluster.Hosts())
+	if err != nil {
+		return nil, fmt.Errorf("resolve key %s for cluster %s: %w", cluster.Key(), cluster.Name(), err)
+	}
+
+	return resolved, nil
+}
+
+func resolve(ctx context.Context, scheme, target string) (string, error) {
+	switch scheme {
+	case domain.KeySchemeEnv:
+		value, ok := os.LookupEnv(target)
+		if !ok {
+			return "", ErrEnvNotSet
+		}
+
+		return strings.TrimSpace(value), nil
+	case domain.KeySchemeFile:
+		content, err := os.ReadFile(target) //nolint:gosec // The key file is chosen by the operator.
+		if err != nil {
+			return "", fmt.Errorf("read key file: %w", err)
+		}
+
+		return strings.TrimSpace(string(content)), nil
+	default:
+		return runCommand(ctx, target)
+	}
+}
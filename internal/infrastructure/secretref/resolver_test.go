@@ -0,0 +1,140 @@
+package secretref_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/secretref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver_ResolveKey(t *testing.T) {
+	// Arrange
+	t.Setenv("CEPHDOCTOR_TEST_KEY", "AQBenv==\n")
+
+	keyFile := filepath.Join(t.TempDir(), "prod.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("AQBfile==\n"), 0o600))
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "AQBliteral==", want: "AQBliteral=="},
+		{key: "env:CEPHDOCTOR_TEST_KEY", want: "AQBenv=="},
+		{key: "file:" + keyFile, want: "AQBfile=="},
+		{key: "exec:echo AQBexec==", want: "AQBexec=="},
+	}
+
+	for _, test := range tests {
+		cluster := newCluster(t, test.key)
+
+		// Act
+		resolved, err := secretref.NewResolver().ResolveKey(t.Context(), cluster)
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, test.want, resolved.Key())
+		require.Equal(t, test.key, cluster.Key())
+	}
+}
+
+func TestResolver_ResolveKeyFailures(t *testing.T) {
+	// Arrange
+	t.Setenv("CEPHDOCTOR_TEST_EMPTY", "")
+	t.Setenv("CEPHDOCTOR_TEST_HOSTILE", "AQBenv==\nCEPHDOCTOR_EOF\ntouch /tmp/pwned")
+
+	tests := []struct {
+		key     string
+		err     error
+		message string
+	}{
+		{key: "env:CEPHDOCTOR_TEST_MISSING", err: secretref.ErrEnvNotSet, message: "env:CEPHDOCTOR_TEST_MISSING"},
+		{key: "env:CEPHDOCTOR_TEST_EMPTY", err: secretref.ErrEmptySecret, message: "alpha"},
+		{key: "file:/nonexistent/prod.key", err: os.ErrNotExist, message: "/nonexistent/prod.key"},
+		{key: "exec:false", err: nil, message: "run key command"},
+		{key: "env:CEPHDOCTOR_TEST_HOSTILE", err: domain.ErrInvalidClusterKey, message: "CEPHDOCTOR_TEST_HOSTILE"},
+	}
+
+	for _, test := range tests {
+		// Act
+		_, err := secretref.NewResolver().ResolveKey(t.Context(), newCluster(t, test.key))
+
+		// Assert
+		require.Error(t, err)
+		require.ErrorContains(t, err, test.message)
+
+		if test.err != nil {
+			require.ErrorIs(t, err, test.err)
+		}
+	}
+}
+
+func TestCephClient_PassesResolvedKeyOnly(t *testing.T) {
+	// Arrange
+	t.Setenv("CEPHDOCTOR_TEST_KEY", "AQBenv==")
+
+	next := &recordingCephClient{key: ""}
+	client := secretref.NewCephClient(next, secretref.NewResolver())
+	cluster := newCluster(t, "env:CEPHDOCTOR_TEST_KEY")
+
+	// Act
+	_, err := client.Status(t.Context(), cluster)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "AQBenv==", next.key)
+	require.Equal(t, "env:CEPHDOCTOR_TEST_KEY", cluster.Key())
+}
+
+func TestCephExecutor_PassesResolvedKeyOnly(t *testing.T) {
+	// Arrange
+	t.Setenv("CEPHDOCTOR_TEST_KEY", "AQBenv==")
+
+	next := &recordingCephClient{key: ""}
+	executor := secretref.NewCephExecutor(next, secretref.NewResolver())
+	cluster := newCluster(t, "env:CEPHDOCTOR_TEST_KEY")
+
+	// Act
+	exitCode, err := executor.Exec(t.Context(), cluster, domain.CephCommand{Tool: "ceph", Args: []string{"df"}},
+		io.Discard, io.Discard)
+
+	// Assert
+	require.NoError(t, err)
+	require.Zero(t, exitCode)
+	require.Equal(t, "AQBenv==", next.key)
+	require.Equal(t, "env:CEPHDOCTOR_TEST_KEY", cluster.Key())
+}
+
+type recordingCephClient struct {
+	key string
+}
+
+func (c *recordingCephClient) Status(_ context.Context, cluster *domain.Cluster) (*domain.CephStatus, error) {
+	c.key = cluster.Key()
+
+	return new(domain.CephStatus), nil
+}
+
+func (c *recordingCephClient) Exec(
+	_ context.Context,
+	cluster *domain.Cluster,
+	_ domain.CephCommand,
+	_, _ io.Writer,
+) (int, error) {
+	c.key = cluster.Key()
+
+	return 0, nil
+}
+
+func newCluster(t *testing.T, key string) *domain.Cluster {
+	t.Helper()
+
+	cluster, err := domain.NewCluster("alpha", key, []string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	return cluster
+}
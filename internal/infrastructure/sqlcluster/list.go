@@ -0,0 +1,65 @@
+package sqlcluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+func (r *Repository) ListClusters(ctx context.Context) ([]*domain.Cluster, error) {
+	clusters, problems, err := r.ListClustersTolerant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(problems) > 0 {
+		return nil, problems[0].Err
+	}
+
+	return clusters, nil
+}
+
+// ListClustersTolerant lists every row that loads, sorted by name, and reports the others as
+// problems named after the cluster. Only failures to query the database are returned as errors.
+func (r *Repository) ListClustersTolerant(ctx context.Context) ([]*domain.Cluster, []domain.LoadProblem, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT name, key, sealed_key, hosts, entity, backend, ssh, labels, ceph_version FROM clusters ORDER BY name")
+	if err != nil {
+		return nil, nil, fmt.Errorf("query clusters: %w", err)
+	}
+	defer rows.Close()
+
+	clusters := make([]*domain.Cluster, 0)
+
+	var problems []domain.LoadProblem
+
+	for rows.Next() {
+		var row clusterRow
+
+		err = rows.Scan(&row.Name, &row.Key, &row.SealedKey, &row.Hosts, &row.Entity, &row.Backend, &row.SSH, &row.Labels,
+			&row.CephVersion)
+		if err != nil {
+			return nil, nil, fmt.Errorf("scan cluster: %w", err)
+		}
+
+		cluster, err := r.toCluster(row)
+		if err != nil {
+			problems = append(problems, domain.LoadProblem{
+				Source: row.Name,
+				Err:    fmt.Errorf("cluster %q: %w", row.Name, err),
+			})
+
+			continue
+		}
+
+		clusters = append(clusters, cluster)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read clusters: %w", err)
+	}
+
+	return clusters, problems, nil
+}
@@ -0,0 +1,52 @@
+package sqlcluster
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/secretbox"
+)
+
+const masterSaltSetting = "master_salt"
+
+var ErrPassphraseRequired = errors.New("cluster key is encrypted but no passphrase is available")
+
+// WithPassphrase seals new and rewritten cluster keys with a key derived from passphrase.
+// The salt is stored in the database so every process derives the same key.
+func WithPassphrase(passphrase []byte) Option {
+	return func(r *Repository) {
+		r.passphrase = passphrase
+	}
+}
+
+// Encrypted reports whether new and rewritten cluster keys are sealed.
+func (r *Repository) Encrypted() bool {
+	return r.box != nil
+}
+
+func (r *Repository) usePassphrase() error {
+	salt, err := secretbox.NewSalt()
+	if err != nil {
+		return fmt.Errorf("new master salt: %w", err)
+	}
+
+	// The first process to store a salt wins; everyone else reads it back.
+	_, err = r.db.Exec("INSERT OR IGNORE INTO settings (name, value) VALUES (?, ?)", masterSaltSetting, salt)
+	if err != nil {
+		return fmt.Errorf("store master salt: %w", err)
+	}
+
+	err = r.db.QueryRow("SELECT value FROM settings WHERE name = ?", masterSaltSetting).Scan(&salt)
+	if err != nil {
+		return fmt.Errorf("read master salt: %w", err)
+	}
+
+	box, err := secretbox.New(r.passphrase, salt)
+	if err != nil {
+		return fmt.Errorf("derive master key: %w", err)
+	}
+
+	r.box = box
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package sqlcluster
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+// clusterRow is the column layout of the clusters table.
+// Key holds a plaintext key; SealedKey replaces it once keys are encrypted at rest.
+type clusterRow struct {
+	Name      string
+	Key       string
+	SealedKey string
+	Hosts     string
+	Entity    string
+	Backend   string
+	SSH       string
+	Labels    string
+
+	CephVersion string
+}
+
+func (r *Repository) newClusterRow(cluster *domain.Cluster) (clusterRow, error) {
+	hosts, err := json.Marshal(cluster.Hosts())
+	if err != nil {
+		return clusterRow{}, fmt.Errorf("marshal cluster hosts: %w", err)
+	}
+
+	labels, err := json.Marshal(cluster.Labels())
+	if err != nil {
+		return clusterRow{}, fmt.Errorf("marshal cluster labels: %w", err)
+	}
+
+	row := clusterRow{
+		Name:      cluster.Name(),
+		Key:       cluster.Key(),
+		SealedKey: "",
+		Hosts:     string(hosts),
+		Entity:    cluster.Entity(),
+		Backend:   string(cluster.Backend()),
+		SSH:       "",
+		Labels:    string(labels),
+
+		CephVersion: cluster.CephVersion(),
+	}
+
+	if target := cluster.SSHTarget(); target != nil {
+		row.SSH = target.String()
+	}
+
+	if r.box != nil {
+		sealed, err := r.box.Seal(row.Key)
+		if err != nil {
+			return clusterRow{}, fmt.Errorf("seal cluster key: %w", err)
+		}
+
+		row.Key, row.SealedKey = "", sealed
+	}
+
+	return row, nil
+}
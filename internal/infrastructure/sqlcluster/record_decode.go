@@ -0,0 +1,55 @@
+package sqlcluster
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+func (r *Repository) toCluster(row clusterRow) (*domain.Cluster, error) {
+	key := row.Key
+
+	if row.SealedKey != "" {
+		if r.box == nil {
+			return nil, ErrPassphraseRequired
+		}
+
+		opened, err := r.box.Open(row.SealedKey)
+		if err != nil {
+			return nil, fmt.Errorf("open cluster key: %w", err)
+		}
+
+		key = opened
+	}
+
+	var hosts []string
+
+	err := json.Unmarshal([]byte(row.Hosts), &hosts)
+	if err != nil {
+		return nil, fmt.Errorf("decode cluster hosts: %w", err)
+	}
+
+	var labels map[string]string
+
+	err = json.Unmarshal([]byte(row.Labels), &labels)
+	if err != nil {
+		return nil, fmt.Errorf("decode cluster labels: %w", err)
+	}
+
+	opts := []domain.ClusterOption{
+		domain.WithBackend(domain.Backend(row.Backend)), domain.WithEntity(row.Entity), domain.WithLabels(labels),
+		domain.WithCephVersion(row.CephVersion),
+	}
+
+	if row.SSH != "" {
+		target, err := domain.ParseSSHTarget(row.SSH)
+		if err != nil {
+			return nil, fmt.Errorf("parse ssh target: %w", err)
+		}
+
+		opts = append(opts, domain.WithSSHTarget(target))
+	}
+
+	return domain.NewCluster(row.Name, key, hosts, opts...) //nolint:wrapcheck // Caller wraps validation errors.
+}
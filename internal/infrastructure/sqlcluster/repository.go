@@ -0,0 +1,92 @@
+// Package sqlcluster provides a SQLite-backed cluster repository.
+// Every cluster is a row in one database file, so writers are serialized by SQLite itself.
+package sqlcluster
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3" // Registers the sqlite3 database/sql driver.
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/secretbox"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/statedir"
+)
+
+const (
+	// DatabaseFileName is the database created in the state directory when no path is given.
+	DatabaseFileName = "clusters.db"
+	dirPerm          = 0o700
+	filePerm         = 0o600
+	busyTimeoutMS    = 5000
+)
+
+var errNilCluster = errors.New("cluster is nil")
+
+type Repository struct {
+	db         *sql.DB
+	passphrase []byte
+	box        *secretbox.Box
+}
+
+// Option configures optional Repository behaviour.
+type Option func(*Repository)
+
+// NewRepository opens the database at path, creating it and its tables when missing.
+// An empty path selects clusters.db in the cephdoctor state directory.
+func NewRepository(path string, opts ...Option) (*Repository, error) {
+	resolvedPath := path
+	if strings.TrimSpace(resolvedPath) == "" {
+		defaultDir, err := statedir.Dir()
+		if err != nil {
+			return nil, fmt.Errorf("resolve default root directory: %w", err)
+		}
+
+		resolvedPath = filepath.Join(defaultDir, DatabaseFileName)
+	}
+
+	err := createDatabaseFile(resolvedPath)
+	if err != nil {
+		return nil, err
+	}
+
+	dsn := fmt.Sprintf("file:%s?_busy_timeout=%d&_txlock=immediate", resolvedPath, busyTimeoutMS)
+
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open cluster database: %w", err)
+	}
+
+	repo := &Repository{db: db, passphrase: nil, box: nil}
+
+	for _, opt := range opts {
+		opt(repo)
+	}
+
+	err = repo.open()
+	if err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// createDatabaseFile creates the database owner-only before SQLite opens it with default permissions.
+func createDatabaseFile(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), dirPerm)
+	if err != nil {
+		return fmt.Errorf("create database directory: %w", err)
+	}
+
+	//nolint:gosec // The database path is chosen by the operator.
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, filePerm)
+	if err != nil {
+		return fmt.Errorf("create cluster database: %w", err)
+	}
+
+	return file.Close() //nolint:wrapcheck // Closing an empty file handle cannot lose data.
+}
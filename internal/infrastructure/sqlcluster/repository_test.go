@@ -0,0 +1,132 @@
+package sqlcluster_test
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+	"github.com/neatflowcv/ceph-doctor/internal/domain/repositorytest"
+	"github.com/neatflowcv/ceph-doctor/internal/infrastructure/sqlcluster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Conformance(t *testing.T) {
+	t.Parallel()
+
+	repositorytest.Run(t, func(t *testing.T) domain.ClusterRepository {
+		t.Helper()
+
+		return openRepository(t, filepath.Join(t.TempDir(), "clusters.db"))
+	})
+}
+
+func TestNewRepository_UsesXDGStateHome(t *testing.T) {
+	// Arrange
+	xdgStateHome := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", xdgStateHome)
+
+	// Act
+	repo, err := sqlcluster.NewRepository("")
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, repo.Close())
+
+	stat, err := os.Stat(filepath.Join(xdgStateHome, "ceph-doctor", "clusters.db"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), stat.Mode().Perm())
+}
+
+func TestRepository_PersistsAcrossReopen(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "clusters.db")
+	repo := openRepository(t, path)
+
+	cluster, err := domain.NewCluster("alpha", "secret", []string{"10.0.0.1"})
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateCluster(t.Context(), cluster))
+
+	// Act
+	reopened := openRepository(t, path)
+	clusters, err := reopened.ListClusters(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+	require.Equal(t, "secret", clusters[0].Key())
+}
+
+func TestRepository_SealsKeysWithPassphrase(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "clusters.db")
+	repo := openRepository(t, path, sqlcluster.WithPassphrase([]byte("correct horse")))
+
+	cluster, err := domain.NewCluster("alpha", "AQBsecretkey==", []string{"10.0.0.1"})
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateCluster(t.Context(), cluster))
+
+	// Act
+	clusters, err := openRepository(t, path, sqlcluster.WithPassphrase([]byte("correct horse"))).
+		ListClusters(t.Context())
+	_, problems, tolerantErr := openRepository(t, path).ListClustersTolerant(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "AQBsecretkey==", clusters[0].Key())
+	require.NoError(t, tolerantErr)
+	require.Len(t, problems, 1)
+	require.Equal(t, "alpha", problems[0].Source)
+	require.ErrorIs(t, problems[0].Err, sqlcluster.ErrPassphraseRequired)
+
+	payload, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(payload), "AQBsecretkey==")
+}
+
+func openRepository(t *testing.T, path string, opts ...sqlcluster.Option) *sqlcluster.Repository {
+	t.Helper()
+
+	repo, err := sqlcluster.NewRepository(path, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, repo.Close()) })
+
+	return repo
+}
+
+func TestNewRepository_MigratesVersion1Database(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "clusters.db")
+	db, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+
+	for _, statement := range []string{
+		`CREATE TABLE clusters (name TEXT PRIMARY KEY, key TEXT NOT NULL, sealed_key TEXT NOT NULL,
+			hosts TEXT NOT NULL, entity TEXT NOT NULL, backend TEXT NOT NULL, ssh TEXT NOT NULL)`,
+		`CREATE TABLE settings (name TEXT PRIMARY KEY, value BLOB NOT NULL)`,
+		`INSERT INTO clusters VALUES ('alpha', 'secret', '', '["10.0.0.1:3300"]', 'client.admin', '', '')`,
+		`PRAGMA user_version = 1`,
+	} {
+		_, err = db.ExecContext(t.Context(), statement)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, db.Close())
+
+	// Act
+	clusters, err := openRepository(t, path).ListClusters(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+	require.Equal(t, "secret", clusters[0].Key())
+	require.Empty(t, clusters[0].Labels())
+	require.Empty(t, clusters[0].CephVersion())
+}
@@ -0,0 +1,29 @@
+package sqlcluster
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+func requireAffectedRow(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("count affected clusters: %w", err)
+	}
+
+	if affected == 0 {
+		return domain.ErrClusterNotFound
+	}
+
+	return nil
+}
+
+func isPrimaryKeyConflict(err error) bool {
+	var sqliteErr sqlite3.Error
+
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+}
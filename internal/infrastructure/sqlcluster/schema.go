@@ -0,0 +1,77 @@
+package sqlcluster
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CurrentSchemaVersion is the database layout written by this build, kept in PRAGMA user_version.
+const CurrentSchemaVersion = 3
+
+var ErrNewerSchemaVersion = errors.New("cluster database was written by a newer cephdoctor")
+
+// open brings the schema up to date and derives the master key when a passphrase was given.
+func (r *Repository) open() error {
+	err := r.migrateSchema()
+	if err != nil {
+		return err
+	}
+
+	if len(r.passphrase) == 0 {
+		return nil
+	}
+
+	return r.usePassphrase()
+}
+
+// migrateSchema applies the missing schema steps in one transaction.
+func (r *Repository) migrateSchema() error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin schema migration: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // Rollback after Commit is a no-op.
+
+	var stored int
+
+	err = tx.QueryRow("PRAGMA user_version").Scan(&stored)
+	if err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+
+	if stored > CurrentSchemaVersion {
+		return fmt.Errorf("%w: schema version %d, this build supports up to %d",
+			ErrNewerSchemaVersion, stored, CurrentSchemaVersion)
+	}
+
+	for version := stored; version < CurrentSchemaVersion; version++ {
+		for _, statement := range schemaSteps[version] {
+			_, err = tx.Exec(statement)
+			if err != nil {
+				return fmt.Errorf("migrate schema version %d: %w", version, err)
+			}
+		}
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", CurrentSchemaVersion))
+	if err != nil {
+		return fmt.Errorf("write schema version: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("commit schema migration: %w", err)
+	}
+
+	return nil
+}
+
+// Close releases the database handle.
+func (r *Repository) Close() error {
+	err := r.db.Close()
+	if err != nil {
+		return fmt.Errorf("close cluster database: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package sqlcluster
+
+// schemaSteps holds the statements that upgrade the database from version i to i+1.
+//
+//nolint:gochecknoglobals // The registry is a fixed table of upgrade steps.
+var schemaSteps = [][]string{
+	{
+		`CREATE TABLE clusters (
+			name       TEXT PRIMARY KEY,
+			key        TEXT NOT NULL,
+			sealed_key TEXT NOT NULL,
+			hosts      TEXT NOT NULL,
+			entity     TEXT NOT NULL,
+			backend    TEXT NOT NULL,
+			ssh        TEXT NOT NULL
+		)`,
+		`CREATE TABLE settings (
+			name  TEXT PRIMARY KEY,
+			value BLOB NOT NULL
+		)`,
+	},
+	{
+		`ALTER TABLE clusters ADD COLUMN labels TEXT NOT NULL DEFAULT '{}'`,
+	},
+	{
+		`ALTER TABLE clusters ADD COLUMN ceph_version TEXT NOT NULL DEFAULT ''`,
+	},
+}
@@ -0,0 +1,97 @@
+package sqlcluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neatflowcv/ceph-doctor/internal/domain"
+)
+
+const (
+	insertStatement = `INSERT INTO clusters (name, key, sealed_key, hosts, entity, backend, ssh, labels, ceph_version)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	updateStatement = `UPDATE clusters SET name = ?, key = ?, sealed_key = ?, hosts = ?, entity = ?,
+	backend = ?, ssh = ?, labels = ?, ceph_version = ? WHERE name = ?`
+)
+
+func (r *Repository) CreateCluster(ctx context.Context, cluster *domain.Cluster) error {
+	if cluster == nil {
+		return errNilCluster
+	}
+
+	row, err := r.newClusterRow(cluster)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, insertStatement,
+		row.Name, row.Key, row.SealedKey, row.Hosts, row.Entity, row.Backend, row.SSH, row.Labels, row.CephVersion)
+	if isPrimaryKeyConflict(err) {
+		return domain.ErrClusterAlreadyExists
+	}
+
+	if err != nil {
+		return fmt.Errorf("insert cluster: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) UpdateCluster(ctx context.Context, cluster *domain.Cluster) error {
+	if cluster == nil {
+		return errNilCluster
+	}
+
+	return r.RenameCluster(ctx, cluster.Name(), cluster)
+}
+
+// RenameCluster stores cluster under its new name in place of oldName in a single statement,
+// so a cluster already stored under the new name is never overwritten.
+func (r *Repository) RenameCluster(ctx context.Context, oldName string, cluster *domain.Cluster) error {
+	if cluster == nil {
+		return errNilCluster
+	}
+
+	row, err := r.newClusterRow(cluster)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, updateStatement,
+		row.Name, row.Key, row.SealedKey, row.Hosts, row.Entity, row.Backend, row.SSH, row.Labels, row.CephVersion,
+		oldName)
+	if isPrimaryKeyConflict(err) {
+		return domain.ErrClusterAlreadyExists
+	}
+
+	if err != nil {
+		return fmt.Errorf("update cluster: %w", err)
+	}
+
+	return requireAffectedRow(result)
+}
+
+func (r *Repository) RecordCephVersion(ctx context.Context, name, version string) error {
+	if version != "" {
+		_, err := domain.ParseCephVersion(version)
+		if err != nil {
+			return fmt.Errorf("record ceph version: %w", err)
+		}
+	}
+
+	result, err := r.db.ExecContext(ctx, "UPDATE clusters SET ceph_version = ? WHERE name = ?", version, name)
+	if err != nil {
+		return fmt.Errorf("record ceph version: %w", err)
+	}
+
+	return requireAffectedRow(result)
+}
+
+func (r *Repository) DeleteCluster(ctx context.Context, name string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM clusters WHERE name = ?", name)
+	if err != nil {
+		return fmt.Errorf("delete cluster: %w", err)
+	}
+
+	return requireAffectedRow(result)
+}
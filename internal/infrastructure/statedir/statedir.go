@@ -1,4 +1,5 @@
-package fscluster
+// Package statedir resolves the directory where cephdoctor keeps its state.
+package statedir
 
 import (
 	"errors"
@@ -11,7 +12,8 @@ const appDirName = "ceph-doctor"
 
 var errHomeNotSet = errors.New("HOME is not set")
 
-func defaultRootDir() (string, error) {
+// Dir returns $XDG_STATE_HOME/ceph-doctor, falling back to ~/.local/state/ceph-doctor.
+func Dir() (string, error) {
 	if xdgStateHome, ok := os.LookupEnv("XDG_STATE_HOME"); ok && strings.TrimSpace(xdgStateHome) != "" {
 		return filepath.Join(xdgStateHome, appDirName), nil
 	}